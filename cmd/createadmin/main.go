@@ -0,0 +1,95 @@
+// Command createadmin creates an admin account directly in the database. It
+// bootstraps the first admin, since public registration only creates asesi
+// accounts and further admins are created by existing admins.
+//
+//	echo "$ADMIN_PASSWORD" | go run ./cmd/createadmin -email admin@lsp.id -username admin -name "Admin LSP"
+//
+// The password is read from the first line of standard input so it does not
+// end up in the shell history or the process list.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"lsp-api/internal/config"
+	"lsp-api/internal/logging"
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+	"lsp-api/migrations"
+
+	"gorm.io/gorm"
+)
+
+const minPasswordLength = 6
+
+func main() {
+	email := flag.String("email", "", "email of the admin")
+	username := flag.String("username", "", "username of the admin")
+	fullName := flag.String("name", "", "full name of the admin")
+	flag.Parse()
+
+	if *email == "" || *username == "" || *fullName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		log.Fatalf("Failed to read password from stdin: %v", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if len(password) < minPasswordLength {
+		log.Fatalf("Password must be at least %d characters", minPasswordLength)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logger, err := logging.New(os.Stderr, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("Invalid LOG_LEVEL: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	db, err := config.InitDB(cfg, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	err = migrations.RunMigrations(db)
+	if err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	userRepo := repositories.NewUserRepository(db)
+
+	_, err = userRepo.FindByEmail(ctx, *email)
+	if err == nil {
+		log.Fatalf("A user with email %s already exists", *email)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Fatalf("Failed to check existing user: %v", err)
+	}
+
+	err = userRepo.Create(ctx, &models.User{
+		Username: *username,
+		FullName: *fullName,
+		Email:    *email,
+		Password: password,
+		Role:     models.RoleAdmin,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create admin: %v", err)
+	}
+
+	log.Printf("Admin %s created", *email)
+}
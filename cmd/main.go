@@ -1,31 +1,88 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"lsp-api/internal/certificate"
 	"lsp-api/internal/config"
 	"lsp-api/internal/controllers"
+	"lsp-api/internal/jwtkeys"
+	"lsp-api/internal/logging"
+	"lsp-api/internal/metrics"
 	"lsp-api/internal/middleware"
+	"lsp-api/internal/ratelimit"
 	"lsp-api/internal/repositories"
+	"lsp-api/internal/routes"
 	"lsp-api/internal/services"
+	"lsp-api/internal/storage"
+	"lsp-api/internal/tracing"
 	"lsp-api/migrations"
 
 	"github.com/gin-gonic/gin"
 )
 
+// shutdownTimeout bounds how long in-flight requests may take to finish once
+// the server is asked to stop.
+const shutdownTimeout = 30 * time.Second
+
 func main() {
+	// Stop on SIGINT or SIGTERM so in-flight requests and traces are not lost
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Initialize structured logging, also used by the standard log package
+	logger, err := logging.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("Invalid LOG_LEVEL: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	err = certificate.ValidateFormat(cfg.CertNumberFormat)
+	if err != nil {
+		log.Fatalf("Invalid CERT_NUMBER_FORMAT: %v", err)
+	}
+
+	err = services.ValidateKelengkapanRules(cfg.AsesorCompletenessRules, cfg.AsesorRequiredFields)
+	if err != nil {
+		log.Fatalf("Invalid asesor completeness configuration: %v", err)
+	}
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
 	// Initialize database
-	db, err := config.InitDB(cfg)
+	db, err := config.InitDB(cfg, logger)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
+	err = tracing.InstrumentDB(db, cfg.DBName)
+	if err != nil {
+		log.Fatalf("Failed to instrument database: %v", err)
+	}
+
+	// Initialize metrics
+	appMetrics := metrics.New()
+	err = appMetrics.InstrumentDB(db, cfg.DBName)
+	if err != nil {
+		log.Fatalf("Failed to instrument database: %v", err)
+	}
 
 	// Run migrations
 	err = migrations.RunMigrations(db)
@@ -37,35 +94,167 @@ func main() {
 	userRepo := repositories.NewUserRepository(db)
 	asesorRepo := repositories.NewAsesorRepository(db)
 	kompetensiRepo := repositories.NewKompetensiRepository(db)
+	invitationRepo := repositories.NewAsesorInvitationRepository(db)
+	jadwalRepo := repositories.NewJadwalRepository(db)
+	asesorKompetensiRepo := repositories.NewAsesorKompetensiRepository(db)
+	dokumenRepo := repositories.NewDokumenRepository(db)
+	asesorMediaRepo := repositories.NewAsesorMediaRepository(db)
+	apl01Repo := repositories.NewAPL01Repository(db)
+	apl02Repo := repositories.NewAPL02Repository(db)
+	unitRepo := repositories.NewUnitKompetensiRepository(db)
+	hasilAsesmenRepo := repositories.NewHasilAsesmenRepository(db)
+	sertifikatRepo := repositories.NewSertifikatRepository(db)
+	tukRepo := repositories.NewTUKRepository(db)
+	ketersediaanRepo := repositories.NewAsesorKetersediaanRepository(db)
+	usulanPenugasanRepo := repositories.NewUsulanPenugasanRepository(db)
+	konflikRepo := repositories.NewKonflikKepentinganRepository(db)
+	laporanRepo := repositories.NewLaporanRepository(db)
+
+	// Initialize document storage
+	fileStorage, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Load the JWT signing keys and keep rotating them in the background
+	jwtKeys, err := jwtkeys.Load(jwtkeys.Options{
+		Dir:              cfg.JWTKeysDir,
+		Algorithm:        cfg.JWTAlgorithm,
+		RotationInterval: cfg.JWTKeyRotation,
+		TokenLifetime:    cfg.JWTExpiry,
+	})
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	go jwtKeys.Run(ctx)
 
 	// Initialize services
-	authService := services.NewAuthService(userRepo, cfg)
-	asesorService := services.NewAsesorService(asesorRepo, kompetensiRepo)
+	authService := services.NewAuthService(userRepo, cfg, appMetrics, jwtKeys)
+	dokumenService := services.NewDokumenService(dokumenRepo, fileStorage, cfg)
+	kelengkapanService := services.NewKelengkapanAsesorService(asesorRepo, asesorKompetensiRepo, cfg)
+	asesorService := services.NewAsesorService(asesorRepo, kompetensiRepo, userRepo, invitationRepo, dokumenService, kelengkapanService)
+	asesorMediaService := services.NewAsesorMediaService(asesorMediaRepo, asesorRepo, dokumenService)
+	asesorKompetensiService := services.NewAsesorKompetensiService(asesorKompetensiRepo, asesorRepo, kompetensiRepo, dokumenService, kelengkapanService)
+	kompetensiService := services.NewKompetensiService(kompetensiRepo, asesorKompetensiRepo, unitRepo)
+	apl01Service := services.NewAPL01Service(apl01Repo, kompetensiRepo, dokumenRepo)
+	apl02Service := services.NewAPL02Service(apl02Repo, apl01Repo, unitRepo, asesorRepo, asesorKompetensiRepo, konflikRepo, dokumenRepo)
+	hasilAsesmenService := services.NewHasilAsesmenService(hasilAsesmenRepo, jadwalRepo, apl01Repo, unitRepo, asesorRepo, konflikRepo)
+	sertifikatService := services.NewSertifikatService(sertifikatRepo, hasilAsesmenRepo, apl01Repo, cfg)
+	tukService := services.NewTUKService(tukRepo, kompetensiRepo)
+	ketersediaanService := services.NewKetersediaanService(ketersediaanRepo, asesorRepo, asesorKompetensiRepo, jadwalRepo)
+	asesorCalendarService := services.NewAsesorCalendarService(userRepo, asesorRepo, jadwalRepo, cfg)
+	penugasanService := services.NewPenugasanService(usulanPenugasanRepo, jadwalRepo, apl01Repo, asesorRepo, asesorKompetensiRepo, konflikRepo, ketersediaanService, cfg)
+	konflikService := services.NewKonflikKepentinganService(konflikRepo, asesorRepo, userRepo)
+	laporanService := services.NewLaporanService(laporanRepo)
+	jadwalService := services.NewJadwalService(jadwalRepo, tukRepo, asesorRepo, kompetensiRepo, asesorKompetensiRepo)
+
+	// Keep asesor completeness flags in step with expiring certifications
+	go kelengkapanService.Run(ctx)
 
 	// Initialize controllers
-	authController := controllers.NewAuthController(authService)
-	asesorController := controllers.NewAsesorController(asesorService)
+	apiControllers := &routes.Controllers{
+		Auth:               controllers.NewAuthController(authService),
+		Asesor:             controllers.NewAsesorController(asesorService, kelengkapanService, dokumenService),
+		Dokumen:            controllers.NewDokumenController(dokumenService),
+		AsesorKompetensi:   controllers.NewAsesorKompetensiController(asesorKompetensiService, dokumenService),
+		AsesorMedia:        controllers.NewAsesorMediaController(asesorMediaService, dokumenService),
+		Kompetensi:         controllers.NewKompetensiController(kompetensiService),
+		Jadwal:             controllers.NewJadwalController(jadwalService),
+		TUK:                controllers.NewTUKController(tukService),
+		Ketersediaan:       controllers.NewKetersediaanController(ketersediaanService, asesorService, asesorCalendarService),
+		Penugasan:          controllers.NewPenugasanController(penugasanService),
+		KonflikKepentingan: controllers.NewKonflikKepentinganController(konflikService),
+		APL01:              controllers.NewAPL01Controller(apl01Service),
+		APL02:              controllers.NewAPL02Controller(apl02Service),
+		HasilAsesmen:       controllers.NewHasilAsesmenController(hasilAsesmenService),
+		Sertifikat:         controllers.NewSertifikatController(sertifikatService),
+		Laporan:            controllers.NewLaporanController(laporanService),
+	}
+
+	appMetrics.CountGauge("asesors_active", "Asesors whose license is currently valid.", func(ctx context.Context) (int64, error) {
+		return asesorRepo.CountActive(ctx, time.Now())
+	})
 
 	// Initialize middleware
 	authMiddleware := middleware.AuthMiddleware(authService)
 
-	// Initialize router
-	router := gin.Default()
+	corsMiddleware, err := middleware.CORS(cfg.CORSAllowedOrigins, cfg.CORSAllowedMethods, cfg.CORSAllowedHeaders, cfg.CORSAllowCredentials)
+	if err != nil {
+		log.Fatalf("Invalid CORS configuration: %v", err)
+	}
 
-	// API routes
-	apiV1 := router.Group("/api/v1")
-	{
-		// Register auth routes
-		authController.RegisterRoutes(apiV1, authMiddleware)
+	// Initialize router, trusting X-Forwarded-For only from the configured proxies
+	router := gin.New()
+	err = router.SetTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	router.Use(
+		middleware.RequestID(),
+		tracing.Middleware(),
+		middleware.AccessLog(logger),
+		appMetrics.Middleware(),
+		middleware.Recovery(logger),
+		middleware.SecurityHeaders(cfg.HSTSMaxAge),
+		corsMiddleware,
+		middleware.Timeout(cfg.RequestTimeout),
+		middleware.Identify(authService),
+		middleware.RateLimit(
+			ratelimit.NewMemoryStore(),
+			ratelimit.PerMinute(cfg.RateLimitAnonymousPerMinute, cfg.RateLimitAnonymousBurst),
+			ratelimit.PerMinute(cfg.RateLimitUserPerMinute, cfg.RateLimitUserBurst),
+		),
+	)
 
-		// Register asesor routes
-		asesorController.RegisterRoutes(apiV1, authMiddleware)
+	// Register API routes and documentation
+	routes.Register(router, authMiddleware, apiControllers)
+
+	// Start the API server, and the metrics server on its own address so
+	// /metrics is not published with the API
+	servers := []*http.Server{{
+		Addr:    fmt.Sprintf(":%s", cfg.AppPort),
+		Handler: router,
+	}}
+	if cfg.MetricsAddr != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", appMetrics.Handler())
+		servers = append(servers, &http.Server{Addr: cfg.MetricsAddr, Handler: metricsMux})
+	}
+
+	serverErr := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			log.Printf("Server starting on %s", server.Addr)
+			serverErr <- server.ListenAndServe()
+		}()
+	}
+
+	failed := false
+	select {
+	case err := <-serverErr:
+		log.Printf("Failed to start server: %v", err)
+		failed = true
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for _, server := range servers {
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Printf("Failed to shut down server on %s: %v", server.Addr, err)
+			failed = true
+		}
+	}
+
+	// Flush the remaining spans after the last request has finished
+	err = shutdownTracing(shutdownCtx)
+	if err != nil {
+		log.Printf("Failed to flush traces: %v", err)
 	}
 
-	// Start server
-	serverAddr := fmt.Sprintf(":%s", cfg.AppPort)
-	log.Printf("Server starting on %s", serverAddr)
-	if err := router.Run(serverAddr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	if failed {
+		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,197 @@
+package bnsp
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	dateLayout = "02-01-2006"
+	sheetName  = "Laporan BNSP"
+)
+
+// kolom is one column of the regulator reporting layout. Wajib columns must
+// be filled before a report can be exported.
+type kolom struct {
+	judul string
+	wajib func(b *models.BarisBNSP) bool
+	nilai func(b *models.BarisBNSP) string
+}
+
+func selalu(*models.BarisBNSP) bool { return true }
+
+func jikaKompeten(b *models.BarisBNSP) bool {
+	return b.Rekomendasi == models.KeputusanKompeten
+}
+
+// layout is the column order of the BNSP reporting template.
+var layout = []kolom{
+	{"Nama Lengkap", selalu, func(b *models.BarisBNSP) string { return b.NamaAsesi }},
+	{"NIK", selalu, func(b *models.BarisBNSP) string { return b.NIK }},
+	{"Tempat Lahir", selalu, func(b *models.BarisBNSP) string { return b.TempatLahir }},
+	{"Tanggal Lahir", selalu, func(b *models.BarisBNSP) string { return formatDate(b.TanggalLahir) }},
+	{"Jenis Kelamin", selalu, func(b *models.BarisBNSP) string { return b.JenisKelamin }},
+	{"Kebangsaan", nil, func(b *models.BarisBNSP) string { return b.Kebangsaan }},
+	{"Alamat", selalu, func(b *models.BarisBNSP) string { return b.AlamatRumah }},
+	{"Kode Pos", nil, func(b *models.BarisBNSP) string { return b.KodePos }},
+	{"No Telepon", selalu, func(b *models.BarisBNSP) string { return b.NoTelepon }},
+	{"Email", nil, func(b *models.BarisBNSP) string { return b.Email }},
+	{"Pendidikan Terakhir", selalu, func(b *models.BarisBNSP) string { return b.PendidikanTerakhir }},
+	{"Institusi", nil, func(b *models.BarisBNSP) string { return b.NamaInstitusi }},
+	{"Jabatan", nil, func(b *models.BarisBNSP) string { return b.Jabatan }},
+	{"Kode Skema", selalu, func(b *models.BarisBNSP) string { return b.KodeSkema }},
+	{"Nama Skema", selalu, func(b *models.BarisBNSP) string { return b.NamaSkema }},
+	{"Kode TUK", selalu, func(b *models.BarisBNSP) string { return b.KodeTUK }},
+	{"Nama TUK", selalu, func(b *models.BarisBNSP) string { return b.NamaTUK }},
+	{"No Registrasi Asesor", selalu, func(b *models.BarisBNSP) string { return b.NoRegistrasiAsesor }},
+	{"Nama Asesor", selalu, func(b *models.BarisBNSP) string { return b.NamaAsesor }},
+	{"Tanggal Uji", selalu, func(b *models.BarisBNSP) string { return formatDate(&b.TanggalUji) }},
+	{"Rekomendasi", selalu, func(b *models.BarisBNSP) string { return b.Rekomendasi }},
+	{"No Sertifikat", jikaKompeten, func(b *models.BarisBNSP) string { return b.NomorSertifikat }},
+	{"Tanggal Terbit Sertifikat", jikaKompeten, func(b *models.BarisBNSP) string { return formatDate(b.TanggalTerbit) }},
+}
+
+// Masalah lists the mandatory columns left empty in one result.
+type Masalah struct {
+	HasilAsesmenID uint     `json:"hasil_asesmen_id"`
+	NamaAsesi      string   `json:"nama_asesi"`
+	KolomKosong    []string `json:"kolom_kosong"`
+}
+
+// Validate reports every row that misses a mandatory column.
+func Validate(baris []models.BarisBNSP) []Masalah {
+	var masalah []Masalah
+	for i := range baris {
+		b := &baris[i]
+
+		var kosong []string
+		for _, k := range layout {
+			if k.wajib != nil && k.wajib(b) && k.nilai(b) == "" {
+				kosong = append(kosong, k.judul)
+			}
+		}
+
+		if len(kosong) > 0 {
+			masalah = append(masalah, Masalah{
+				HasilAsesmenID: b.HasilAsesmenID,
+				NamaAsesi:      b.NamaAsesi,
+				KolomKosong:    kosong,
+			})
+		}
+	}
+	return masalah
+}
+
+// Write renders the rows as CSV or XLSX in the BNSP column layout, numbered
+// from one.
+func Write(w io.Writer, format string, baris []models.BarisBNSP) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, baris)
+	case FormatXLSX:
+		return writeXLSX(w, baris)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func header() []string {
+	judul := []string{"No"}
+	for _, k := range layout {
+		judul = append(judul, k.judul)
+	}
+	return judul
+}
+
+func record(no int, b *models.BarisBNSP) []string {
+	nilai := []string{strconv.Itoa(no)}
+	for _, k := range layout {
+		nilai = append(nilai, k.nilai(b))
+	}
+	return nilai
+}
+
+func writeCSV(w io.Writer, baris []models.BarisBNSP) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(header())
+	if err != nil {
+		return err
+	}
+
+	for i := range baris {
+		nilai := record(i+1, &baris[i])
+		for j := range nilai {
+			nilai[j] = escapeFormula(nilai[j])
+		}
+
+		err = cw.Write(nilai)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeFormula keeps spreadsheet applications from evaluating a value typed
+// by an asesi as a formula when the CSV is opened, by prefixing a quote to
+// values starting with a formula trigger. XLSX cells are written as text and
+// need no escaping.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func writeXLSX(w io.Writer, baris []models.BarisBNSP) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	err := f.SetSheetName(f.GetSheetName(0), sheetName)
+	if err != nil {
+		return err
+	}
+
+	// Every cell is written as text so NIK and phone numbers keep their
+	// leading zeros
+	rows := [][]string{header()}
+	for i := range baris {
+		rows = append(rows, record(i+1, &baris[i]))
+	}
+
+	for r, row := range rows {
+		for c, value := range row {
+			cell, err := excelize.CoordinatesToCellName(c+1, r+1)
+			if err != nil {
+				return err
+			}
+			err = f.SetCellStr(sheetName, cell, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
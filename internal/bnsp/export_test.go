@@ -0,0 +1,78 @@
+package bnsp
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"lsp-api/internal/models"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"Budi Santoso", "Budi Santoso"},
+		{"0812345678", "0812345678"},
+		{"=HYPERLINK(\"http://evil\",\"klik\")", "'=HYPERLINK(\"http://evil\",\"klik\")"},
+		{"+628123456789", "'+628123456789"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=1+1", "'\t=1+1"},
+		{"\r=1+1", "'\r=1+1"},
+		{"Jl. Merdeka =1", "Jl. Merdeka =1"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeFormula(tt.value); got != tt.want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	baris := []models.BarisBNSP{{
+		NamaAsesi:     "=HYPERLINK(\"http://evil\")",
+		AlamatRumah:   "@cmd",
+		Jabatan:       "-1+1",
+		NamaInstitusi: "PT Maju",
+		TanggalUji:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Rekomendasi:   models.KeputusanKompeten,
+	}}
+
+	var buf bytes.Buffer
+	err := Write(&buf, FormatCSV, baris)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want header and one record", len(rows))
+	}
+
+	got := map[string]string{}
+	for i, judul := range rows[0] {
+		got[judul] = rows[1][i]
+	}
+
+	want := map[string]string{
+		"No":           "1",
+		"Nama Lengkap": "'=HYPERLINK(\"http://evil\")",
+		"Alamat":       "'@cmd",
+		"Jabatan":      "'-1+1",
+		"Institusi":    "PT Maju",
+		"Tanggal Uji":  "19-10-2026",
+	}
+	for judul, nilai := range want {
+		if got[judul] != nilai {
+			t.Errorf("column %q = %q, want %q", judul, got[judul], nilai)
+		}
+	}
+}
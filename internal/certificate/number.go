@@ -0,0 +1,68 @@
+package certificate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Number formats are templates such as "LSP-{year}-{seq:06}". Supported
+// tokens are {seq} (optionally zero-padded to a width), {year}, {month} and
+// {kode}, the kode of the kompetensi being certified.
+var tokenPattern = regexp.MustCompile(`\{(seq|year|month|kode)(?::(\d+))?\}`)
+
+// ValidateFormat rejects formats that cannot produce unique numbers or that
+// would not survive as a single URL path segment.
+func ValidateFormat(format string) error {
+	seqCount := 0
+	for _, match := range tokenPattern.FindAllStringSubmatch(format, -1) {
+		if match[1] == "seq" {
+			seqCount++
+		}
+	}
+	if seqCount != 1 {
+		return errors.New("certificate number format must contain exactly one {seq} token")
+	}
+
+	rest := tokenPattern.ReplaceAllString(format, "")
+	if strings.ContainsAny(rest, "{}/?#% ") {
+		return fmt.Errorf("certificate number format contains invalid characters: %q", format)
+	}
+	return nil
+}
+
+// Scope returns the counter key a number belongs to. Sequences restart for
+// every distinct scope, e.g. each year when the format contains {year}.
+func Scope(format, kode string, issuedAt time.Time) string {
+	return render(format, kode, issuedAt, func(string) string { return "{seq}" })
+}
+
+// FormatNumber renders the certificate number for the given sequence value.
+func FormatNumber(format, kode string, issuedAt time.Time, seq uint64) string {
+	return render(format, kode, issuedAt, func(width string) string {
+		if width == "" {
+			return strconv.FormatUint(seq, 10)
+		}
+		w, _ := strconv.Atoi(width)
+		return fmt.Sprintf("%0*d", w, seq)
+	})
+}
+
+func render(format, kode string, issuedAt time.Time, seq func(width string) string) string {
+	return tokenPattern.ReplaceAllStringFunc(format, func(token string) string {
+		match := tokenPattern.FindStringSubmatch(token)
+		switch match[1] {
+		case "seq":
+			return seq(match[2])
+		case "year":
+			return strconv.Itoa(issuedAt.Year())
+		case "month":
+			return fmt.Sprintf("%02d", int(issuedAt.Month()))
+		default:
+			return strings.ReplaceAll(kode, "/", "-")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package certificate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"LSP-{year}-{seq:06}", false},
+		{"{kode}.{month}.{year}.{seq}", false},
+		{"{seq}", false},
+		{"LSP-{year}", true},
+		{"", true},
+		{"{seq}-{seq:04}", true},
+		{"LSP/{year}/{seq}", true},
+		{"LSP {seq}", true},
+		{"LSP-{seq}?x", true},
+		{"LSP-{seq}#", true},
+		{"LSP-{seq}%20", true},
+		{"LSP-{serial}-{seq}", true},
+		{"LSP-{seq:}", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateFormat(tt.format)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateFormat(%q) = %v, want error %v", tt.format, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScope(t *testing.T) {
+	issuedAt := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format string
+		kode   string
+		want   string
+	}{
+		{"LSP-{year}-{seq:06}", "TIK.01", "LSP-2026-{seq}"},
+		{"{kode}/{month}-{seq}", "TIK/01", "TIK-01/03-{seq}"},
+		{"{seq}", "TIK.01", "{seq}"},
+	}
+
+	for _, tt := range tests {
+		if got := Scope(tt.format, tt.kode, issuedAt); got != tt.want {
+			t.Errorf("Scope(%q, %q) = %q, want %q", tt.format, tt.kode, got, tt.want)
+		}
+	}
+
+	// Padding does not change the scope, a new year does
+	if Scope("LSP-{year}-{seq:06}", "", issuedAt) != Scope("LSP-{year}-{seq}", "", issuedAt) {
+		t.Errorf("sequence width changed the scope")
+	}
+	if Scope("LSP-{year}-{seq}", "", issuedAt) == Scope("LSP-{year}-{seq}", "", issuedAt.AddDate(1, 0, 0)) {
+		t.Errorf("scope did not change with the year")
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	issuedAt := time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format string
+		kode   string
+		seq    uint64
+		want   string
+	}{
+		{"LSP-{year}-{seq:06}", "TIK.01", 42, "LSP-2026-000042"},
+		{"LSP-{year}-{seq:03}", "TIK.01", 12345, "LSP-2026-12345"},
+		{"{kode}.{month}.{seq}", "TIK/01", 7, "TIK-01.11.7"},
+		{"{seq}", "", 1, "1"},
+		{"{seq:1}", "", 0, "0"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatNumber(tt.format, tt.kode, issuedAt, tt.seq); got != tt.want {
+			t.Errorf("FormatNumber(%q, %q, %d) = %q, want %q", tt.format, tt.kode, tt.seq, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+
+	"lsp-api/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const dateLayout = "02 January 2006"
+
+// RenderPDF draws a landscape A4 certificate with a QR code pointing to the
+// public verification URL.
+func RenderPDF(sertifikat *models.Sertifikat, verifyURL string) ([]byte, error) {
+	qr, err := qrcode.Encode(verifyURL, qrcode.Medium, 512)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Sertifikat Kompetensi %s", sertifikat.NomorSertifikat), true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	width, height := pdf.GetPageSize()
+
+	pdf.SetLineWidth(1.2)
+	pdf.Rect(10, 10, width-20, height-20, "D")
+	pdf.SetLineWidth(0.3)
+	pdf.Rect(13, 13, width-26, height-26, "D")
+
+	pdf.SetY(30)
+	pdf.SetFont("Helvetica", "B", 30)
+	pdf.CellFormat(0, 14, "SERTIFIKAT KOMPETENSI", "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(0, 8, fmt.Sprintf("No. %s", sertifikat.NomorSertifikat), "", 1, "C", false, 0, "")
+
+	pdf.Ln(10)
+	pdf.CellFormat(0, 8, "Diberikan kepada", "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 24)
+	pdf.CellFormat(0, 14, sertifikat.NamaPemegang, "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(0, 8, "telah dinyatakan KOMPETEN pada skema", "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, sertifikat.Kompetensi.Nama, "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(0, 7, sertifikat.Kompetensi.Kode, "", 1, "C", false, 0, "")
+
+	pdf.Ln(8)
+	pdf.CellFormat(0, 7, fmt.Sprintf("Diterbitkan %s, berlaku sampai %s",
+		sertifikat.TanggalTerbit.Format(dateLayout), sertifikat.BerlakuSampai.Format(dateLayout)), "", 1, "C", false, 0, "")
+
+	qrSize := 38.0
+	qrX, qrY := width-20-qrSize, height-20-qrSize-6
+	pdf.RegisterImageOptionsReader("qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", qrX, qrY, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetXY(20, height-24)
+	pdf.CellFormat(width-40, 4, fmt.Sprintf("Verifikasi keaslian sertifikat: %s", verifyURL), "", 0, "R", false, 0, verifyURL)
+
+	var buf bytes.Buffer
+	err = pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render certificate: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,10 +17,58 @@ type Config struct {
 	DBPassword string
 	DBName     string
 
-	JWTSecret string
-	JWTExpiry string
+	JWTExpiry      time.Duration
+	JWTKeysDir     string
+	JWTAlgorithm   string
+	JWTKeyRotation time.Duration
+	JWTIssuer      string
+	JWTAudience    string
 
-	AppPort string
+	AppPort     string
+	AppBaseURL  string
+	MetricsAddr string
+
+	LogLevel             string
+	DBSlowQueryThreshold time.Duration
+	RequestTimeout       time.Duration
+
+	TrustedProxies       []string
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSAllowCredentials bool
+	HSTSMaxAge           time.Duration
+
+	TracingExporter     string
+	TracingOTLPEndpoint string
+	TracingServiceName  string
+	TracingSampleRatio  float64
+
+	RateLimitAnonymousPerMinute int
+	RateLimitAnonymousBurst     int
+	RateLimitUserPerMinute      int
+	RateLimitUserBurst          int
+
+	UploadDir          string
+	StorageDriver      string
+	MaxUploadSizeMB    int64
+	AllowedUploadTypes []string
+	FileSigningKey     string
+
+	S3Endpoint  string
+	S3AccessKey string
+	S3SecretKey string
+	S3Bucket    string
+	S3Region    string
+	S3UseSSL    bool
+
+	CertNumberFormat  string
+	CertValidityYears int
+
+	MaxAsesiPerAsesor int
+
+	AsesorCompletenessRules []string
+	AsesorRequiredFields    []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -26,6 +77,81 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("error loading .env file: %w", err)
 	}
 
+	jwtExpiry, err := time.ParseDuration(getEnv("JWT_EXPIRY", "24h"))
+	if err != nil || jwtExpiry <= 0 {
+		return nil, fmt.Errorf("invalid JWT_EXPIRY: %q", os.Getenv("JWT_EXPIRY"))
+	}
+
+	jwtKeyRotationHours, err := strconv.Atoi(getEnv("JWT_KEY_ROTATION_HOURS", "720"))
+	if err != nil || jwtKeyRotationHours < 0 {
+		return nil, fmt.Errorf("invalid JWT_KEY_ROTATION_HOURS: %q", os.Getenv("JWT_KEY_ROTATION_HOURS"))
+	}
+
+	maxUploadSizeMB, err := strconv.ParseInt(getEnv("MAX_UPLOAD_SIZE_MB", "10"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAX_UPLOAD_SIZE_MB: %w", err)
+	}
+
+	s3UseSSL, err := strconv.ParseBool(getEnv("S3_USE_SSL", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3_USE_SSL: %w", err)
+	}
+
+	certValidityYears, err := strconv.Atoi(getEnv("CERT_VALIDITY_YEARS", "3"))
+	if err != nil || certValidityYears <= 0 {
+		return nil, fmt.Errorf("invalid CERT_VALIDITY_YEARS: %q", os.Getenv("CERT_VALIDITY_YEARS"))
+	}
+
+	maxAsesiPerAsesor, err := strconv.Atoi(getEnv("MAX_ASESI_PER_ASESOR", "10"))
+	if err != nil || maxAsesiPerAsesor <= 0 {
+		return nil, fmt.Errorf("invalid MAX_ASESI_PER_ASESOR: %q", os.Getenv("MAX_ASESI_PER_ASESOR"))
+	}
+
+	dbSlowQueryMs, err := strconv.Atoi(getEnv("DB_SLOW_QUERY_MS", "200"))
+	if err != nil || dbSlowQueryMs < 0 {
+		return nil, fmt.Errorf("invalid DB_SLOW_QUERY_MS: %q", os.Getenv("DB_SLOW_QUERY_MS"))
+	}
+
+	requestTimeoutSeconds, err := strconv.Atoi(getEnv("REQUEST_TIMEOUT_SECONDS", "30"))
+	if err != nil || requestTimeoutSeconds < 0 {
+		return nil, fmt.Errorf("invalid REQUEST_TIMEOUT_SECONDS: %q", os.Getenv("REQUEST_TIMEOUT_SECONDS"))
+	}
+
+	tracingSampleRatio, err := strconv.ParseFloat(getEnv("TRACING_SAMPLE_RATIO", "1"), 64)
+	if err != nil || tracingSampleRatio < 0 || tracingSampleRatio > 1 {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO: %q", os.Getenv("TRACING_SAMPLE_RATIO"))
+	}
+
+	rateLimitAnonymousPerMinute, err := strconv.Atoi(getEnv("RATE_LIMIT_ANONYMOUS_PER_MINUTE", "30"))
+	if err != nil || rateLimitAnonymousPerMinute < 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ANONYMOUS_PER_MINUTE: %q", os.Getenv("RATE_LIMIT_ANONYMOUS_PER_MINUTE"))
+	}
+
+	rateLimitAnonymousBurst, err := strconv.Atoi(getEnv("RATE_LIMIT_ANONYMOUS_BURST", "10"))
+	if err != nil || rateLimitAnonymousBurst < 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ANONYMOUS_BURST: %q", os.Getenv("RATE_LIMIT_ANONYMOUS_BURST"))
+	}
+
+	rateLimitUserPerMinute, err := strconv.Atoi(getEnv("RATE_LIMIT_USER_PER_MINUTE", "300"))
+	if err != nil || rateLimitUserPerMinute < 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_USER_PER_MINUTE: %q", os.Getenv("RATE_LIMIT_USER_PER_MINUTE"))
+	}
+
+	rateLimitUserBurst, err := strconv.Atoi(getEnv("RATE_LIMIT_USER_BURST", "60"))
+	if err != nil || rateLimitUserBurst < 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_USER_BURST: %q", os.Getenv("RATE_LIMIT_USER_BURST"))
+	}
+
+	corsAllowCredentials, err := strconv.ParseBool(getEnv("CORS_ALLOW_CREDENTIALS", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CORS_ALLOW_CREDENTIALS: %w", err)
+	}
+
+	hstsMaxAgeSeconds, err := strconv.Atoi(getEnv("HSTS_MAX_AGE_SECONDS", "31536000"))
+	if err != nil || hstsMaxAgeSeconds < 0 {
+		return nil, fmt.Errorf("invalid HSTS_MAX_AGE_SECONDS: %q", os.Getenv("HSTS_MAX_AGE_SECONDS"))
+	}
+
 	config := &Config{
 		DBHost:     os.Getenv("DB_HOST"),
 		DBPort:     os.Getenv("DB_PORT"),
@@ -33,10 +159,72 @@ func LoadConfig() (*Config, error) {
 		DBPassword: os.Getenv("DB_PASSWORD"),
 		DBName:     os.Getenv("DB_NAME"),
 
-		JWTSecret: os.Getenv("JWT_SECRET"),
-		JWTExpiry: os.Getenv("JWT_EXPIRY"),
+		JWTExpiry:      jwtExpiry,
+		JWTKeysDir:     getEnv("JWT_KEYS_DIR", "keys"),
+		JWTAlgorithm:   getEnv("JWT_ALGORITHM", "RS256"),
+		JWTKeyRotation: time.Duration(jwtKeyRotationHours) * time.Hour,
+		JWTAudience:    getEnv("JWT_AUDIENCE", "lsp-api"),
+
+		AppPort:     os.Getenv("APP_PORT"),
+		AppBaseURL:  strings.TrimRight(os.Getenv("APP_BASE_URL"), "/"),
+		MetricsAddr: getEnv("METRICS_ADDR", "127.0.0.1:9090"),
+
+		LogLevel:             getEnv("LOG_LEVEL", "info"),
+		DBSlowQueryThreshold: time.Duration(dbSlowQueryMs) * time.Millisecond,
+		RequestTimeout:       time.Duration(requestTimeoutSeconds) * time.Second,
+
+		TrustedProxies:       splitList(os.Getenv("TRUSTED_PROXIES")),
+		CORSAllowedOrigins:   splitList(os.Getenv("CORS_ALLOWED_ORIGINS")),
+		CORSAllowedMethods:   splitList(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS")),
+		CORSAllowedHeaders:   splitList(getEnv("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,X-Request-ID")),
+		CORSAllowCredentials: corsAllowCredentials,
+		HSTSMaxAge:           time.Duration(hstsMaxAgeSeconds) * time.Second,
+
+		TracingExporter:     getEnv("TRACING_EXPORTER", "none"),
+		TracingOTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "http://localhost:4318/v1/traces"),
+		TracingServiceName:  getEnv("TRACING_SERVICE_NAME", "lsp-api"),
+		TracingSampleRatio:  tracingSampleRatio,
+
+		RateLimitAnonymousPerMinute: rateLimitAnonymousPerMinute,
+		RateLimitAnonymousBurst:     rateLimitAnonymousBurst,
+		RateLimitUserPerMinute:      rateLimitUserPerMinute,
+		RateLimitUserBurst:          rateLimitUserBurst,
+
+		UploadDir:          getEnv("UPLOAD_DIR", "uploads"),
+		StorageDriver:      getEnv("STORAGE_DRIVER", "local"),
+		MaxUploadSizeMB:    maxUploadSizeMB,
+		AllowedUploadTypes: splitList(getEnv("ALLOWED_UPLOAD_TYPES", "application/pdf,image/jpeg,image/png")),
+		FileSigningKey:     os.Getenv("FILE_SIGNING_KEY"),
 
-		AppPort: os.Getenv("APP_PORT"),
+		S3Endpoint:  os.Getenv("S3_ENDPOINT"),
+		S3AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey: os.Getenv("S3_SECRET_KEY"),
+		S3Bucket:    os.Getenv("S3_BUCKET"),
+		S3Region:    os.Getenv("S3_REGION"),
+		S3UseSSL:    s3UseSSL,
+
+		CertNumberFormat:  getEnv("CERT_NUMBER_FORMAT", "LSP-{year}-{seq:06}"),
+		CertValidityYears: certValidityYears,
+
+		MaxAsesiPerAsesor: maxAsesiPerAsesor,
+
+		AsesorCompletenessRules: splitList(getEnv("ASESOR_COMPLETENESS_RULES", "profil,kompetensi,lisensi,dokumen_lisensi")),
+		AsesorRequiredFields:    splitList(getEnv("ASESOR_REQUIRED_FIELDS", "nama_lengkap,no_registrasi,email,no_telepon,instansi")),
+	}
+
+	// Tokens name the API that issued them, which is its public URL by default
+	config.JWTIssuer = getEnv("JWT_ISSUER", config.AppBaseURL)
+	if config.JWTIssuer == "" {
+		return nil, fmt.Errorf("JWT_ISSUER or APP_BASE_URL is required")
+	}
+
+	// Metrics are served on their own listener, kept off the public API port
+	if config.MetricsAddr == "none" {
+		config.MetricsAddr = ""
+	}
+
+	if config.FileSigningKey == "" {
+		return nil, fmt.Errorf("FILE_SIGNING_KEY is required")
 	}
 
 	return config, nil
@@ -45,4 +233,21 @@ func LoadConfig() (*Config, error) {
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
 		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName)
-}
\ No newline at end of file
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
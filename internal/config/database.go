@@ -2,23 +2,34 @@ package config
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
+
+	"lsp-api/internal/logging"
+	"lsp-api/internal/models"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-func InitDB(config *Config) (*gorm.DB, error) {
+func InitDB(config *Config, logger *slog.Logger) (*gorm.DB, error) {
 	dsn := config.GetDSN()
 
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: logging.NewGormLogger(logger, config.DBSlowQueryThreshold),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Println("Database connection established")
+	// Use the certification entity as the asesor_kompetensi join table
+	err = db.SetupJoinTable(&models.Asesor{}, "Kompetensi", &models.AsesorKompetensi{})
+	if err == nil {
+		err = db.SetupJoinTable(&models.Kompetensi{}, "Asesor", &models.AsesorKompetensi{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up asesor_kompetensi join table: %w", err)
+	}
+
+	logger.Info("Database connection established")
 	return db, nil
-}
\ No newline at end of file
+}
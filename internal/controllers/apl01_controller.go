@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APL01Controller struct {
+	apl01Service services.APL01Service
+}
+
+func NewAPL01Controller(apl01Service services.APL01Service) *APL01Controller {
+	return &APL01Controller{
+		apl01Service: apl01Service,
+	}
+}
+
+type APL01Request struct {
+	KompetensiID       uint   `json:"kompetensi_id" binding:"required"`
+	NamaLengkap        string `json:"nama_lengkap" binding:"required,min=3,max=150"`
+	NIK                string `json:"nik" binding:"required,len=16,numeric"`
+	TempatLahir        string `json:"tempat_lahir" binding:"required,max=100"`
+	TanggalLahir       string `json:"tanggal_lahir" binding:"required,datetime=2006-01-02"`
+	JenisKelamin       string `json:"jenis_kelamin" binding:"required,oneof=L P"`
+	Kebangsaan         string `json:"kebangsaan" binding:"required,max=50"`
+	AlamatRumah        string `json:"alamat_rumah" binding:"required"`
+	KodePos            string `json:"kode_pos" binding:"max=10"`
+	NoTelepon          string `json:"no_telepon" binding:"required,max=20"`
+	Email              string `json:"email" binding:"required,email"`
+	PendidikanTerakhir string `json:"pendidikan_terakhir" binding:"required,max=50"`
+	NamaInstitusi      string `json:"nama_institusi" binding:"max=150"`
+	Jabatan            string `json:"jabatan" binding:"max=100"`
+	AlamatKantor       string `json:"alamat_kantor"`
+	TeleponKantor      string `json:"telepon_kantor" binding:"max=20"`
+	EmailKantor        string `json:"email_kantor" binding:"omitempty,email"`
+	TujuanAsesmen      string `json:"tujuan_asesmen" binding:"required,oneof=sertifikasi resertifikasi pkt rpl lainnya"`
+}
+
+type AttachDokumenRequest struct {
+	DokumenID  uint   `json:"dokumen_id" binding:"required"`
+	Keterangan string `json:"keterangan" binding:"max=255"`
+}
+
+type ReviewAPL01Request struct {
+	Status  string `json:"status" binding:"required,oneof=perlu_revisi disetujui ditolak"`
+	Catatan string `json:"catatan"`
+}
+
+func (r *APL01Request) toModel() *models.APL01 {
+	// The date was already validated by the datetime binding
+	tanggalLahir, _ := time.Parse(utils.DateLayout, r.TanggalLahir)
+
+	return &models.APL01{
+		KompetensiID:       r.KompetensiID,
+		NamaLengkap:        r.NamaLengkap,
+		NIK:                r.NIK,
+		TempatLahir:        r.TempatLahir,
+		TanggalLahir:       &tanggalLahir,
+		JenisKelamin:       r.JenisKelamin,
+		Kebangsaan:         r.Kebangsaan,
+		AlamatRumah:        r.AlamatRumah,
+		KodePos:            r.KodePos,
+		NoTelepon:          r.NoTelepon,
+		Email:              r.Email,
+		PendidikanTerakhir: r.PendidikanTerakhir,
+		NamaInstitusi:      r.NamaInstitusi,
+		Jabatan:            r.Jabatan,
+		AlamatKantor:       r.AlamatKantor,
+		TeleponKantor:      r.TeleponKantor,
+		EmailKantor:        r.EmailKantor,
+		TujuanAsesmen:      r.TujuanAsesmen,
+	}
+}
+
+func apl01ErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrAPL01NotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAPL01NotEditable), errors.Is(err, services.ErrInvalidStatusTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (c *APL01Controller) CreateAPL01(ctx *gin.Context) {
+	var req APL01Request
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl01, err := c.apl01Service.CreateAPL01(ctx.Request.Context(), ctx.GetUint("userID"), req.toModel())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("APL-01 created successfully", apl01))
+}
+
+func (c *APL01Controller) UpdateAPL01(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-01 ID"))
+		return
+	}
+
+	var req APL01Request
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl01, err := c.apl01Service.UpdateAPL01(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.toModel())
+	if err != nil {
+		ctx.JSON(apl01ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-01 updated successfully", apl01))
+}
+
+func (c *APL01Controller) GetAPL01(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-01 ID"))
+		return
+	}
+
+	apl01, err := c.apl01Service.GetAPL01ByID(ctx.Request.Context(), uint(id))
+	if err != nil || (ctx.GetString("role") != models.RoleAdmin && apl01.AsesiID != ctx.GetUint("userID")) {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("APL-01 not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-01 retrieved successfully", apl01))
+}
+
+func (c *APL01Controller) GetAllAPL01(ctx *gin.Context) {
+	var (
+		apl01 []models.APL01
+		err   error
+	)
+
+	// Staff see every submission, an asesi only their own
+	if ctx.GetString("role") == models.RoleAdmin {
+		apl01, err = c.apl01Service.GetAllAPL01(ctx.Request.Context(), ctx.Query("status"))
+	} else {
+		apl01, err = c.apl01Service.GetAPL01ByAsesi(ctx.Request.Context(), ctx.GetUint("userID"))
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve APL-01"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-01 retrieved successfully", apl01))
+}
+
+func (c *APL01Controller) AttachDokumen(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-01 ID"))
+		return
+	}
+
+	var req AttachDokumenRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl01, err := c.apl01Service.AttachDokumen(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.DokumenID, req.Keterangan)
+	if err != nil {
+		ctx.JSON(apl01ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Dokumen attached successfully", apl01))
+}
+
+func (c *APL01Controller) DetachDokumen(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-01 ID"))
+		return
+	}
+
+	dokumenID, err := strconv.ParseUint(ctx.Param("dokumen_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid dokumen ID"))
+		return
+	}
+
+	apl01, err := c.apl01Service.DetachDokumen(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), uint(dokumenID))
+	if err != nil {
+		ctx.JSON(apl01ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Dokumen detached successfully", apl01))
+}
+
+func (c *APL01Controller) SubmitAPL01(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-01 ID"))
+		return
+	}
+
+	apl01, err := c.apl01Service.SubmitAPL01(ctx.Request.Context(), uint(id), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(apl01ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-01 submitted successfully", apl01))
+}
+
+func (c *APL01Controller) ReviewAPL01(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-01 ID"))
+		return
+	}
+
+	var req ReviewAPL01Request
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl01, err := c.apl01Service.ReviewAPL01(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.Status, req.Catatan)
+	if err != nil {
+		ctx.JSON(apl01ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-01 reviewed successfully", apl01))
+}
+
+func (c *APL01Controller) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	asesiOnly := middleware.RequireRole(models.RoleAsesi)
+
+	apl01Router := router.Group("/apl01", authMiddleware)
+	{
+		apl01Router.GET("/", middleware.RequireRole(models.RoleAsesi, models.RoleAdmin), c.GetAllAPL01)
+		apl01Router.GET("/:id", middleware.RequireRole(models.RoleAsesi, models.RoleAdmin), c.GetAPL01)
+		apl01Router.POST("/", asesiOnly, c.CreateAPL01)
+		apl01Router.PUT("/:id", asesiOnly, c.UpdateAPL01)
+		apl01Router.POST("/:id/dokumen", asesiOnly, c.AttachDokumen)
+		apl01Router.DELETE("/:id/dokumen/:dokumen_id", asesiOnly, c.DetachDokumen)
+		apl01Router.POST("/:id/submit", asesiOnly, c.SubmitAPL01)
+		apl01Router.POST("/:id/review", middleware.RequireRole(models.RoleAdmin), c.ReviewAPL01)
+	}
+}
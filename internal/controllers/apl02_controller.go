@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APL02Controller struct {
+	apl02Service services.APL02Service
+}
+
+func NewAPL02Controller(apl02Service services.APL02Service) *APL02Controller {
+	return &APL02Controller{
+		apl02Service: apl02Service,
+	}
+}
+
+type GenerateAPL02Request struct {
+	APL01ID uint `json:"apl01_id" binding:"required"`
+}
+
+type AnswerAPL02ItemRequest struct {
+	Jawaban  string `json:"jawaban" binding:"required,oneof=K BK"`
+	BuktiIDs []uint `json:"bukti_ids"`
+}
+
+type ReviewAPL02Request struct {
+	Rekomendasi string `json:"rekomendasi" binding:"required,oneof=lanjut tidak_lanjut"`
+	Catatan     string `json:"catatan"`
+}
+
+func apl02ErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrAPL01NotFound), errors.Is(err, services.ErrAPL02NotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAPL02NotEditable), errors.Is(err, services.ErrInvalidStatusTransition):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrAsesorLicenseInvalid), errors.Is(err, services.ErrAsesorNotQualified),
+		errors.Is(err, services.ErrConflictOfInterest):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (c *APL02Controller) GenerateAPL02(ctx *gin.Context) {
+	var req GenerateAPL02Request
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl02, err := c.apl02Service.GenerateAPL02(ctx.Request.Context(), req.APL01ID, ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(apl02ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-02 generated successfully", apl02))
+}
+
+func (c *APL02Controller) GetAPL02(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-02 ID"))
+		return
+	}
+
+	apl02, err := c.apl02Service.GetAPL02ByID(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), ctx.GetString("role"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("APL-02 not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-02 retrieved successfully", apl02))
+}
+
+func (c *APL02Controller) GetAllAPL02(ctx *gin.Context) {
+	apl02, err := c.apl02Service.GetAPL02List(ctx.Request.Context(), ctx.GetUint("userID"), ctx.GetString("role"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve APL-02"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-02 retrieved successfully", apl02))
+}
+
+func (c *APL02Controller) AnswerItem(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-02 ID"))
+		return
+	}
+
+	itemID, err := strconv.ParseUint(ctx.Param("item_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid item ID"))
+		return
+	}
+
+	var req AnswerAPL02ItemRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl02, err := c.apl02Service.AnswerItem(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), uint(itemID), req.Jawaban, req.BuktiIDs)
+	if err != nil {
+		ctx.JSON(apl02ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Answer saved successfully", apl02))
+}
+
+func (c *APL02Controller) SubmitAPL02(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-02 ID"))
+		return
+	}
+
+	apl02, err := c.apl02Service.SubmitAPL02(ctx.Request.Context(), uint(id), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(apl02ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-02 submitted successfully", apl02))
+}
+
+func (c *APL02Controller) AssignAsesor(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-02 ID"))
+		return
+	}
+
+	var req AssignAsesorRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl02, err := c.apl02Service.AssignAsesor(ctx.Request.Context(), uint(id), req.AsesorID)
+	if err != nil {
+		ctx.JSON(apl02ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor assigned successfully", apl02))
+}
+
+func (c *APL02Controller) ReviewAPL02(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid APL-02 ID"))
+		return
+	}
+
+	var req ReviewAPL02Request
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	apl02, err := c.apl02Service.ReviewAPL02(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.Rekomendasi, req.Catatan)
+	if err != nil {
+		ctx.JSON(apl02ErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("APL-02 reviewed successfully", apl02))
+}
+
+func (c *APL02Controller) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	asesiOnly := middleware.RequireRole(models.RoleAsesi)
+
+	apl02Router := router.Group("/apl02", authMiddleware)
+	{
+		apl02Router.GET("/", c.GetAllAPL02)
+		apl02Router.GET("/:id", c.GetAPL02)
+		apl02Router.POST("/", asesiOnly, c.GenerateAPL02)
+		apl02Router.PUT("/:id/items/:item_id", asesiOnly, c.AnswerItem)
+		apl02Router.POST("/:id/submit", asesiOnly, c.SubmitAPL02)
+		apl02Router.PUT("/:id/asesor", middleware.RequireRole(models.RoleAdmin), c.AssignAsesor)
+		apl02Router.POST("/:id/review", middleware.RequireRole(models.RoleAsesor), c.ReviewAPL02)
+	}
+}
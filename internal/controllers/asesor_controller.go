@@ -3,7 +3,10 @@ package controllers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
 	"lsp-api/internal/services"
 	"lsp-api/internal/utils"
 
@@ -11,12 +14,20 @@ import (
 )
 
 type AsesorController struct {
-	asesorService services.AsesorService
+	asesorService      services.AsesorService
+	kelengkapanService services.KelengkapanAsesorService
+	dokumenService     services.DokumenService
 }
 
-func NewAsesorController(asesorService services.AsesorService) *AsesorController {
+func NewAsesorController(
+	asesorService services.AsesorService,
+	kelengkapanService services.KelengkapanAsesorService,
+	dokumenService services.DokumenService,
+) *AsesorController {
 	return &AsesorController{
-		asesorService: asesorService,
+		asesorService:      asesorService,
+		kelengkapanService: kelengkapanService,
+		dokumenService:     dokumenService,
 	}
 }
 
@@ -25,6 +36,7 @@ type CreateAsesorRequest struct {
 	NoRegistrasi string `json:"no_registrasi" binding:"required,min=3,max=50"`
 	Email        string `json:"email" binding:"required,email"`
 	NoTelepon    string `json:"no_telepon" binding:"required"`
+	Instansi     string `json:"instansi" binding:"max=150"`
 	KompetensiID []uint `json:"kompetensi_id" binding:"required"`
 }
 
@@ -33,9 +45,34 @@ type UpdateAsesorRequest struct {
 	NoRegistrasi string `json:"no_registrasi" binding:"required,min=3,max=50"`
 	Email        string `json:"email" binding:"required,email"`
 	NoTelepon    string `json:"no_telepon" binding:"required"`
+	Instansi     string `json:"instansi" binding:"max=150"`
 	KompetensiID []uint `json:"kompetensi_id" binding:"required"`
 }
 
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Username string `json:"username" binding:"required,min=3,max=50"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type UpdateOwnContactRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	NoTelepon string `json:"no_telepon" binding:"required"`
+}
+
+type UpdateLisensiRequest struct {
+	TanggalTerbit     string `json:"tanggal_terbit" binding:"required,datetime=2006-01-02"`
+	TanggalKadaluarsa string `json:"tanggal_kadaluarsa" binding:"required,datetime=2006-01-02"`
+	Penerbit          string `json:"penerbit" binding:"required,max=100"`
+	Status            string `json:"status" binding:"required,oneof=aktif dibekukan dicabut"`
+}
+
+// UpdateKelengkapanRequest sets the completeness flag manually. Sending null
+// clears the manual value so the flag is computed from the rules again.
+type UpdateKelengkapanRequest struct {
+	IsComplete *bool `json:"is_complete"`
+}
+
 func (c *AsesorController) CreateAsesor(ctx *gin.Context) {
 	var req CreateAsesorRequest
 
@@ -46,10 +83,12 @@ func (c *AsesorController) CreateAsesor(ctx *gin.Context) {
 	}
 
 	asesor, err := c.asesorService.CreateAsesor(
+		ctx.Request.Context(),
 		req.NamaLengkap,
 		req.NoRegistrasi,
 		req.Email,
 		req.NoTelepon,
+		req.Instansi,
 		req.KompetensiID,
 	)
 
@@ -77,11 +116,13 @@ func (c *AsesorController) UpdateAsesor(ctx *gin.Context) {
 	}
 
 	asesor, err := c.asesorService.UpdateAsesor(
+		ctx.Request.Context(),
 		uint(id),
 		req.NamaLengkap,
 		req.NoRegistrasi,
 		req.Email,
 		req.NoTelepon,
+		req.Instansi,
 		req.KompetensiID,
 	)
 
@@ -100,7 +141,7 @@ func (c *AsesorController) DeleteAsesor(ctx *gin.Context) {
 		return
 	}
 
-	err = c.asesorService.DeleteAsesor(uint(id))
+	err = c.asesorService.DeleteAsesor(ctx.Request.Context(), uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
 		return
@@ -116,7 +157,7 @@ func (c *AsesorController) GetAsesor(ctx *gin.Context) {
 		return
 	}
 
-	asesor, err := c.asesorService.GetAsesorByID(uint(id))
+	asesor, err := c.asesorService.GetAsesorByID(ctx.Request.Context(), uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor not found"))
 		return
@@ -126,7 +167,19 @@ func (c *AsesorController) GetAsesor(ctx *gin.Context) {
 }
 
 func (c *AsesorController) GetAllAsesors(ctx *gin.Context) {
-	asesors, err := c.asesorService.GetAllAsesors()
+	var asesors []models.Asesor
+	var err error
+
+	if value := ctx.Query("is_complete"); value != "" {
+		isComplete, parseErr := strconv.ParseBool(value)
+		if parseErr != nil {
+			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid is_complete parameter"))
+			return
+		}
+		asesors, err = c.kelengkapanService.GetAsesorsByKelengkapan(ctx.Request.Context(), isComplete)
+	} else {
+		asesors, err = c.asesorService.GetAllAsesors(ctx.Request.Context())
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve asesors"))
 		return
@@ -138,7 +191,7 @@ func (c *AsesorController) GetAllAsesors(ctx *gin.Context) {
 func (c *AsesorController) GetAsesorByNoRegistrasi(ctx *gin.Context) {
 	noRegistrasi := ctx.Param("no_registrasi")
 
-	asesor, err := c.asesorService.GetAsesorByNoRegistrasi(noRegistrasi)
+	asesor, err := c.asesorService.GetAsesorByNoRegistrasi(ctx.Request.Context(), noRegistrasi)
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor not found"))
 		return
@@ -147,8 +200,202 @@ func (c *AsesorController) GetAsesorByNoRegistrasi(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor retrieved successfully", asesor))
 }
 
+func (c *AsesorController) InviteAsesor(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	invitation, err := c.asesorService.InviteAsesor(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Invitation created successfully", invitation))
+}
+
+func (c *AsesorController) AcceptInvitation(ctx *gin.Context) {
+	var req AcceptInvitationRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	user, err := c.asesorService.AcceptInvitation(ctx.Request.Context(), req.Token, req.Username, req.Password)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Invitation accepted successfully", user))
+}
+
+func (c *AsesorController) GetMe(ctx *gin.Context) {
+	asesor, err := c.asesorService.GetAsesorByUserID(ctx.Request.Context(), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor profile not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor retrieved successfully", asesor))
+}
+
+func (c *AsesorController) GetMyKompetensi(ctx *gin.Context) {
+	asesor, err := c.asesorService.GetAsesorByUserID(ctx.Request.Context(), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor profile not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Kompetensi retrieved successfully", asesor.Kompetensi))
+}
+
+func (c *AsesorController) UpdateMyContact(ctx *gin.Context) {
+	var req UpdateOwnContactRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	asesor, err := c.asesorService.UpdateOwnContact(ctx.Request.Context(), ctx.GetUint("userID"), req.Email, req.NoTelepon)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Contact details updated successfully", asesor))
+}
+
+func (c *AsesorController) UpdateLisensi(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	var req UpdateLisensiRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	// Both dates were already validated by the datetime binding
+	tanggalTerbit, _ := time.Parse(utils.DateLayout, req.TanggalTerbit)
+	tanggalKadaluarsa, _ := time.Parse(utils.DateLayout, req.TanggalKadaluarsa)
+
+	asesor, err := c.asesorService.UpdateLisensi(ctx.Request.Context(), uint(id), tanggalTerbit, tanggalKadaluarsa, req.Penerbit, req.Status)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor license updated successfully", asesor))
+}
+
+func (c *AsesorController) GetExpiringLisensi(ctx *gin.Context) {
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", "30"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid days parameter"))
+		return
+	}
+
+	asesors, err := c.asesorService.GetAsesorsWithExpiringLisensi(ctx.Request.Context(), days)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesors retrieved successfully", asesors))
+}
+
+func (c *AsesorController) GetKelengkapan(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	kelengkapan, err := c.kelengkapanService.GetKelengkapan(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor completeness retrieved successfully", kelengkapan))
+}
+
+func (c *AsesorController) UpdateKelengkapan(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	var req UpdateKelengkapanRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	kelengkapan, err := c.kelengkapanService.SetKelengkapan(ctx.Request.Context(), uint(id), req.IsComplete)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor completeness updated successfully", kelengkapan))
+}
+
+func (c *AsesorController) UploadDokumenLisensi(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	fileName, file, ok := receiveUpload(ctx, c.dokumenService)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	asesor, err := c.asesorService.UploadDokumenLisensi(ctx.Request.Context(), uint(id), fileName, file, ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(uploadErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("License document uploaded successfully", asesor))
+}
+
+func (c *AsesorController) DownloadDokumenLisensi(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	dokumenID, err := c.asesorService.GetDokumenLisensiID(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	serveDokumen(ctx, c.dokumenService, dokumenID)
+}
+
 func (c *AsesorController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
-	asesorRouter := router.Group("/asesors", authMiddleware)
+	asesorRouter := router.Group("/asesors", authMiddleware, middleware.RequireRole(models.RoleAdmin))
 	{
 		asesorRouter.POST("/", c.CreateAsesor)
 		asesorRouter.PUT("/:id", c.UpdateAsesor)
@@ -156,5 +403,21 @@ func (c *AsesorController) RegisterRoutes(router *gin.RouterGroup, authMiddlewar
 		asesorRouter.GET("/:id", c.GetAsesor)
 		asesorRouter.GET("/", c.GetAllAsesors)
 		asesorRouter.GET("/registrasi/:no_registrasi", c.GetAsesorByNoRegistrasi)
+		asesorRouter.POST("/:id/invitations", c.InviteAsesor)
+		asesorRouter.PUT("/:id/lisensi", c.UpdateLisensi)
+		asesorRouter.GET("/lisensi/expiring", c.GetExpiringLisensi)
+		asesorRouter.PUT("/:id/lisensi/dokumen", c.UploadDokumenLisensi)
+		asesorRouter.GET("/:id/lisensi/dokumen", c.DownloadDokumenLisensi)
+		asesorRouter.GET("/:id/completeness", c.GetKelengkapan)
+		asesorRouter.PATCH("/:id/completeness", c.UpdateKelengkapan)
+	}
+
+	router.POST("/asesor/invitations/accept", c.AcceptInvitation)
+
+	meRouter := router.Group("/asesor/me", authMiddleware, middleware.RequireRole(models.RoleAsesor))
+	{
+		meRouter.GET("", c.GetMe)
+		meRouter.GET("/kompetensi", c.GetMyKompetensi)
+		meRouter.PUT("", c.UpdateMyContact)
 	}
 }
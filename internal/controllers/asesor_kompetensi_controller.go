@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AsesorKompetensiController struct {
+	asesorKompetensiService services.AsesorKompetensiService
+	dokumenService          services.DokumenService
+}
+
+func NewAsesorKompetensiController(asesorKompetensiService services.AsesorKompetensiService, dokumenService services.DokumenService) *AsesorKompetensiController {
+	return &AsesorKompetensiController{
+		asesorKompetensiService: asesorKompetensiService,
+		dokumenService:          dokumenService,
+	}
+}
+
+type SaveSertifikasiRequest struct {
+	NomorSertifikat string `json:"nomor_sertifikat" binding:"required,max=100"`
+	BerlakuMulai    string `json:"berlaku_mulai" binding:"required,datetime=2006-01-02"`
+	BerlakuSampai   string `json:"berlaku_sampai" binding:"required,datetime=2006-01-02"`
+}
+
+func parseAsesorKompetensiIDs(ctx *gin.Context) (uint, uint, bool) {
+	asesorID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return 0, 0, false
+	}
+
+	kompetensiID, err := strconv.ParseUint(ctx.Param("kompetensi_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid kompetensi ID"))
+		return 0, 0, false
+	}
+
+	return uint(asesorID), uint(kompetensiID), true
+}
+
+func (c *AsesorKompetensiController) GetSertifikasi(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	sertifikasi, err := c.asesorKompetensiService.GetSertifikasi(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Sertifikasi retrieved successfully", sertifikasi))
+}
+
+func (c *AsesorKompetensiController) SaveSertifikasi(ctx *gin.Context) {
+	asesorID, kompetensiID, ok := parseAsesorKompetensiIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var req SaveSertifikasiRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	// Both dates were already validated by the datetime binding
+	berlakuMulai, _ := time.Parse(utils.DateLayout, req.BerlakuMulai)
+	berlakuSampai, _ := time.Parse(utils.DateLayout, req.BerlakuSampai)
+
+	sertifikasi, err := c.asesorKompetensiService.SaveSertifikasi(ctx.Request.Context(), asesorID, kompetensiID, req.NomorSertifikat, berlakuMulai, berlakuSampai)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Sertifikasi saved successfully", sertifikasi))
+}
+
+func (c *AsesorKompetensiController) RemoveSertifikasi(ctx *gin.Context) {
+	asesorID, kompetensiID, ok := parseAsesorKompetensiIDs(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.asesorKompetensiService.RemoveSertifikasi(ctx.Request.Context(), asesorID, kompetensiID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Sertifikasi deleted successfully", nil))
+}
+
+func (c *AsesorKompetensiController) UploadDokumenBukti(ctx *gin.Context) {
+	asesorID, kompetensiID, ok := parseAsesorKompetensiIDs(ctx)
+	if !ok {
+		return
+	}
+
+	fileName, file, ok := receiveUpload(ctx, c.dokumenService)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	sertifikasi, err := c.asesorKompetensiService.UploadDokumenBukti(ctx.Request.Context(), asesorID, kompetensiID, fileName, file, ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(uploadErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Evidence document uploaded successfully", sertifikasi))
+}
+
+func (c *AsesorKompetensiController) DownloadDokumenBukti(ctx *gin.Context) {
+	asesorID, kompetensiID, ok := parseAsesorKompetensiIDs(ctx)
+	if !ok {
+		return
+	}
+
+	dokumenID, err := c.asesorKompetensiService.GetDokumenBuktiID(ctx.Request.Context(), asesorID, kompetensiID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	serveDokumen(ctx, c.dokumenService, dokumenID)
+}
+
+func (c *AsesorKompetensiController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	sertifikasiRouter := router.Group("/asesors/:id/kompetensi", authMiddleware, middleware.RequireRole(models.RoleAdmin))
+	{
+		sertifikasiRouter.GET("", c.GetSertifikasi)
+		sertifikasiRouter.PUT("/:kompetensi_id", c.SaveSertifikasi)
+		sertifikasiRouter.DELETE("/:kompetensi_id", c.RemoveSertifikasi)
+		sertifikasiRouter.PUT("/:kompetensi_id/dokumen", c.UploadDokumenBukti)
+		sertifikasiRouter.GET("/:kompetensi_id/dokumen", c.DownloadDokumenBukti)
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"lsp-api/internal/imaging"
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AsesorMediaController struct {
+	asesorMediaService services.AsesorMediaService
+	dokumenService     services.DokumenService
+}
+
+func NewAsesorMediaController(asesorMediaService services.AsesorMediaService, dokumenService services.DokumenService) *AsesorMediaController {
+	return &AsesorMediaController{
+		asesorMediaService: asesorMediaService,
+		dokumenService:     dokumenService,
+	}
+}
+
+func (c *AsesorMediaController) upload(ctx *gin.Context, jenis string) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	_, file, ok := receiveUpload(ctx, c.dokumenService)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	media, err := c.asesorMediaService.UploadMedia(ctx.Request.Context(), uint(id), jenis, file, ctx.GetUint("userID"))
+	if err != nil {
+		status := uploadErrorStatus(err)
+		if errors.Is(err, imaging.ErrInvalidDimensions) {
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor media uploaded successfully", media))
+}
+
+func (c *AsesorMediaController) serve(ctx *gin.Context, jenis string) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	thumbnail := ctx.Query("variant") == "thumbnail"
+
+	dokumen, content, err := c.asesorMediaService.OpenMedia(ctx.Request.Context(), uint(id), jenis, thumbnail)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor media not found"))
+		return
+	}
+	defer content.Close()
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.DataFromReader(http.StatusOK, dokumen.Ukuran, dokumen.ContentType, content, nil)
+}
+
+func (c *AsesorMediaController) UploadPhoto(ctx *gin.Context) {
+	c.upload(ctx, models.JenisMediaFoto)
+}
+
+func (c *AsesorMediaController) GetPhoto(ctx *gin.Context) {
+	c.serve(ctx, models.JenisMediaFoto)
+}
+
+func (c *AsesorMediaController) UploadSignature(ctx *gin.Context) {
+	c.upload(ctx, models.JenisMediaTandaTangan)
+}
+
+func (c *AsesorMediaController) GetSignature(ctx *gin.Context) {
+	c.serve(ctx, models.JenisMediaTandaTangan)
+}
+
+func (c *AsesorMediaController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	mediaRouter := router.Group("/asesors/:id", authMiddleware, middleware.RequireRole(models.RoleAdmin))
+	{
+		mediaRouter.PUT("/photo", c.UploadPhoto)
+		mediaRouter.GET("/photo", c.GetPhoto)
+		mediaRouter.PUT("/signature", c.UploadSignature)
+		mediaRouter.GET("/signature", c.GetSignature)
+	}
+}
@@ -3,6 +3,8 @@ package controllers
 import (
 	"net/http"
 
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
 	"lsp-api/internal/services"
 	"lsp-api/internal/utils"
 
@@ -35,7 +37,17 @@ type LoginResponse struct {
 	Token string `json:"token"`
 }
 
+// Register creates another admin account. It is only open to admins; the
+// first admin is created with cmd/createadmin.
 func (c *AuthController) Register(ctx *gin.Context) {
+	c.register(ctx, models.RoleAdmin)
+}
+
+func (c *AuthController) RegisterAsesi(ctx *gin.Context) {
+	c.register(ctx, models.RoleAsesi)
+}
+
+func (c *AuthController) register(ctx *gin.Context, role string) {
 	var req RegisterRequest
 
 	valid, _ := utils.ValidateRequest(ctx, &req)
@@ -44,7 +56,7 @@ func (c *AuthController) Register(ctx *gin.Context) {
 		return
 	}
 
-	err := c.authService.Register(req.Username, req.FullName, req.Email, req.Password)
+	err := c.authService.Register(ctx.Request.Context(), req.Username, req.FullName, req.Email, req.Password, role)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
 		return
@@ -62,7 +74,7 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.authService.Login(req.Email, req.Password)
+	token, err := c.authService.Login(ctx.Request.Context(), req.Email, req.Password)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse(err.Error()))
 		return
@@ -76,10 +88,18 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, utils.SuccessResponse("Logged out successfully", nil))
 }
 
+// JWKS publishes the token verification keys in the standard JWK Set format,
+// without the response envelope, so off-the-shelf JWT libraries can use it.
+func (c *AuthController) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.authService.JWKS(ctx.Request.Context()))
+}
+
 func (c *AuthController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	authRouter := router.Group("/auth")
 	{
-		authRouter.POST("/register", c.Register)
+		authRouter.POST("/register", authMiddleware, middleware.RequireRole(models.RoleAdmin), c.Register)
+		authRouter.POST("/register/asesi", c.RegisterAsesi)
 		authRouter.POST("/login", c.Login)
 		authRouter.POST("/logout", authMiddleware, c.Logout)
 	}
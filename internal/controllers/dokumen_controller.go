@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DokumenController struct {
+	dokumenService services.DokumenService
+}
+
+func NewDokumenController(dokumenService services.DokumenService) *DokumenController {
+	return &DokumenController{
+		dokumenService: dokumenService,
+	}
+}
+
+type UploadDokumenRequest struct {
+	Kategori string `form:"kategori" binding:"required,oneof=ktp ijazah portfolio formulir sertifikat lainnya"`
+}
+
+type DownloadURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// receiveUpload reads the "file" form field, bounding the request body by the
+// configured upload limit. It writes the error response itself on failure.
+func receiveUpload(ctx *gin.Context, dokumenService services.DokumenService) (string, io.ReadCloser, bool) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, dokumenService.MaxUploadSize()+(1<<20))
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, utils.ErrorResponse(services.ErrFileTooLarge.Error()))
+			return "", nil, false
+		}
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("File is required"))
+		return "", nil, false
+	}
+
+	if fileHeader.Size > dokumenService.MaxUploadSize() {
+		ctx.JSON(http.StatusRequestEntityTooLarge, utils.ErrorResponse(services.ErrFileTooLarge.Error()))
+		return "", nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Failed to read uploaded file"))
+		return "", nil, false
+	}
+
+	return fileHeader.Filename, file, true
+}
+
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrUnsupportedFileType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func canAccessDokumen(ctx *gin.Context, dokumen *models.Dokumen) bool {
+	return ctx.GetString("role") == models.RoleAdmin || dokumen.UploadedBy == ctx.GetUint("userID")
+}
+
+func (c *DokumenController) UploadDokumen(ctx *gin.Context) {
+	fileName, file, ok := receiveUpload(ctx, c.dokumenService)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	var req UploadDokumenRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	dokumen, err := c.dokumenService.UploadDokumen(ctx.Request.Context(), fileName, file, req.Kategori, ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(uploadErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Dokumen uploaded successfully", dokumen))
+}
+
+func (c *DokumenController) GetDokumen(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid dokumen ID"))
+		return
+	}
+
+	dokumen, err := c.dokumenService.GetDokumen(ctx.Request.Context(), uint(id))
+	if err != nil || !canAccessDokumen(ctx, dokumen) {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Dokumen not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Dokumen retrieved successfully", dokumen))
+}
+
+func (c *DokumenController) DeleteDokumen(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid dokumen ID"))
+		return
+	}
+
+	dokumen, err := c.dokumenService.GetDokumen(ctx.Request.Context(), uint(id))
+	if err != nil || !canAccessDokumen(ctx, dokumen) {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Dokumen not found"))
+		return
+	}
+
+	err = c.dokumenService.DeleteDokumen(ctx.Request.Context(), uint(id))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrDokumenInUse) {
+			status = http.StatusConflict
+		}
+		ctx.JSON(status, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Dokumen deleted successfully", nil))
+}
+
+func (c *DokumenController) CreateDownloadURL(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid dokumen ID"))
+		return
+	}
+
+	ttl, err := time.ParseDuration(ctx.DefaultQuery("ttl", "15m"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid ttl parameter"))
+		return
+	}
+
+	dokumen, err := c.dokumenService.GetDokumen(ctx.Request.Context(), uint(id))
+	if err != nil || !canAccessDokumen(ctx, dokumen) {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Dokumen not found"))
+		return
+	}
+
+	url, expiresAt, err := c.dokumenService.CreateDownloadURL(ctx.Request.Context(), uint(id), ttl)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Download URL created successfully", DownloadURLResponse{
+		URL:       url,
+		ExpiresAt: expiresAt,
+	}))
+}
+
+func (c *DokumenController) DownloadDokumen(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid dokumen ID"))
+		return
+	}
+
+	expires, err := strconv.ParseInt(ctx.Query("expires"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, utils.ErrorResponse(services.ErrInvalidSignature.Error()))
+		return
+	}
+
+	err = c.dokumenService.VerifyDownloadSignature(ctx.Request.Context(), uint(id), expires, ctx.Query("signature"))
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	serveDokumen(ctx, c.dokumenService, uint(id))
+}
+
+// serveDokumen streams a stored document to the client as an attachment.
+func serveDokumen(ctx *gin.Context, dokumenService services.DokumenService, id uint) {
+	dokumen, content, err := dokumenService.OpenDokumen(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Dokumen not found"))
+		return
+	}
+	defer content.Close()
+
+	ctx.Header("X-Checksum-SHA256", dokumen.Checksum)
+	ctx.DataFromReader(http.StatusOK, dokumen.Ukuran, dokumen.ContentType, content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", dokumen.NamaFile),
+	})
+}
+
+func (c *DokumenController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	// Signed downloads are authorized by the URL signature rather than a token
+	router.GET("/dokumen/:id/download", c.DownloadDokumen)
+
+	dokumenRouter := router.Group("/dokumen", authMiddleware)
+	{
+		dokumenRouter.POST("/", c.UploadDokumen)
+		dokumenRouter.GET("/:id", c.GetDokumen)
+		dokumenRouter.DELETE("/:id", c.DeleteDokumen)
+		dokumenRouter.GET("/:id/url", c.CreateDownloadURL)
+	}
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HasilAsesmenController struct {
+	hasilAsesmenService services.HasilAsesmenService
+}
+
+func NewHasilAsesmenController(hasilAsesmenService services.HasilAsesmenService) *HasilAsesmenController {
+	return &HasilAsesmenController{
+		hasilAsesmenService: hasilAsesmenService,
+	}
+}
+
+type HasilAsesmenRequest struct {
+	MetodeAsesmen []string                  `json:"metode_asesmen" binding:"dive,oneof=observasi tertulis lisan portofolio"`
+	Rekomendasi   string                    `json:"rekomendasi" binding:"omitempty,oneof=K BK"`
+	Catatan       string                    `json:"catatan"`
+	Units         []HasilAsesmenUnitRequest `json:"units" binding:"dive"`
+}
+
+type HasilAsesmenUnitRequest struct {
+	UnitKompetensiID uint   `json:"unit_kompetensi_id" binding:"required"`
+	Keputusan        string `json:"keputusan" binding:"required,oneof=K BK"`
+	Catatan          string `json:"catatan"`
+}
+
+type CreateHasilAsesmenRequest struct {
+	JadwalID uint `json:"jadwal_id" binding:"required"`
+	APL01ID  uint `json:"apl01_id" binding:"required"`
+	HasilAsesmenRequest
+}
+
+func (r *HasilAsesmenRequest) toModel() *models.HasilAsesmen {
+	hasil := &models.HasilAsesmen{
+		MetodeAsesmen: r.MetodeAsesmen,
+		Rekomendasi:   r.Rekomendasi,
+		Catatan:       r.Catatan,
+	}
+
+	for _, unit := range r.Units {
+		hasil.Units = append(hasil.Units, models.HasilAsesmenUnit{
+			UnitKompetensiID: unit.UnitKompetensiID,
+			Keputusan:        unit.Keputusan,
+			Catatan:          unit.Catatan,
+		})
+	}
+
+	return hasil
+}
+
+func hasilAsesmenErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrHasilAsesmenNotFound), errors.Is(err, services.ErrAPL01NotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAsesorNotAssigned), errors.Is(err, services.ErrAsesiNotAssigned):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrHasilAsesmenLocked):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrConflictOfInterest):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (c *HasilAsesmenController) CreateHasil(ctx *gin.Context) {
+	var req CreateHasilAsesmenRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	data := req.toModel()
+	data.JadwalID = req.JadwalID
+	data.APL01ID = req.APL01ID
+
+	hasil, err := c.hasilAsesmenService.CreateHasil(ctx.Request.Context(), ctx.GetUint("userID"), data)
+	if err != nil {
+		ctx.JSON(hasilAsesmenErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Hasil asesmen created successfully", hasil))
+}
+
+func (c *HasilAsesmenController) UpdateHasil(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid hasil asesmen ID"))
+		return
+	}
+
+	var req HasilAsesmenRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	hasil, err := c.hasilAsesmenService.UpdateHasil(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.toModel())
+	if err != nil {
+		ctx.JSON(hasilAsesmenErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Hasil asesmen updated successfully", hasil))
+}
+
+func (c *HasilAsesmenController) SignOffHasil(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid hasil asesmen ID"))
+		return
+	}
+
+	hasil, err := c.hasilAsesmenService.SignOffHasil(ctx.Request.Context(), uint(id), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(hasilAsesmenErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Hasil asesmen signed off successfully", hasil))
+}
+
+func (c *HasilAsesmenController) GetHasil(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid hasil asesmen ID"))
+		return
+	}
+
+	hasil, err := c.hasilAsesmenService.GetHasilByID(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), ctx.GetString("role"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Hasil asesmen not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Hasil asesmen retrieved successfully", hasil))
+}
+
+func (c *HasilAsesmenController) GetAllHasil(ctx *gin.Context) {
+	hasil, err := c.hasilAsesmenService.GetHasilList(ctx.Request.Context(), ctx.GetUint("userID"), ctx.GetString("role"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve hasil asesmen"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Hasil asesmen retrieved successfully", hasil))
+}
+
+func (c *HasilAsesmenController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	asesorOnly := middleware.RequireRole(models.RoleAsesor)
+
+	hasilRouter := router.Group("/hasil-asesmen", authMiddleware)
+	{
+		hasilRouter.GET("/", c.GetAllHasil)
+		hasilRouter.GET("/:id", c.GetHasil)
+		hasilRouter.POST("/", asesorOnly, c.CreateHasil)
+		hasilRouter.PUT("/:id", asesorOnly, c.UpdateHasil)
+		hasilRouter.POST("/:id/sign-off", asesorOnly, c.SignOffHasil)
+	}
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JadwalController struct {
+	jadwalService services.JadwalService
+}
+
+func NewJadwalController(jadwalService services.JadwalService) *JadwalController {
+	return &JadwalController{
+		jadwalService: jadwalService,
+	}
+}
+
+type CreateJadwalRequest struct {
+	KompetensiID   uint      `json:"kompetensi_id" binding:"required"`
+	TUKID          *uint     `json:"tuk_id"`
+	TanggalMulai   time.Time `json:"tanggal_mulai" binding:"required"`
+	TanggalSelesai time.Time `json:"tanggal_selesai" binding:"required"`
+	Lokasi         string    `json:"lokasi" binding:"max=255"`
+}
+
+type AssignAsesorRequest struct {
+	AsesorID uint `json:"asesor_id" binding:"required"`
+}
+
+func (c *JadwalController) CreateJadwal(ctx *gin.Context) {
+	var req CreateJadwalRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	jadwal, err := c.jadwalService.CreateJadwal(ctx.Request.Context(), req.KompetensiID, req.TUKID, req.TanggalMulai, req.TanggalSelesai, req.Lokasi)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrTUKNotVerified) || errors.Is(err, services.ErrTUKKompetensiNotAllowed) ||
+			errors.Is(err, services.ErrTUKCapacityExceeded) {
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Jadwal created successfully", jadwal))
+}
+
+func (c *JadwalController) GetJadwal(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid jadwal ID"))
+		return
+	}
+
+	jadwal, err := c.jadwalService.GetJadwalByID(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Jadwal not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Jadwal retrieved successfully", jadwal))
+}
+
+func (c *JadwalController) GetAllJadwal(ctx *gin.Context) {
+	jadwal, err := c.jadwalService.GetAllJadwal(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve jadwal"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Jadwal retrieved successfully", jadwal))
+}
+
+func (c *JadwalController) AssignAsesor(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid jadwal ID"))
+		return
+	}
+
+	var req AssignAsesorRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	jadwal, err := c.jadwalService.AssignAsesor(ctx.Request.Context(), uint(id), req.AsesorID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrAsesorLicenseInvalid) || errors.Is(err, services.ErrAsesorNotQualified) {
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor assigned successfully", jadwal))
+}
+
+func (c *JadwalController) UnassignAsesor(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid jadwal ID"))
+		return
+	}
+
+	asesorID, err := strconv.ParseUint(ctx.Param("asesor_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	jadwal, err := c.jadwalService.UnassignAsesor(ctx.Request.Context(), uint(id), uint(asesorID))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor unassigned successfully", jadwal))
+}
+
+func (c *JadwalController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	jadwalRouter := router.Group("/jadwal", authMiddleware, middleware.RequireRole(models.RoleAdmin))
+	{
+		jadwalRouter.POST("/", c.CreateJadwal)
+		jadwalRouter.GET("/:id", c.GetJadwal)
+		jadwalRouter.GET("/", c.GetAllJadwal)
+		jadwalRouter.POST("/:id/asesors", c.AssignAsesor)
+		jadwalRouter.DELETE("/:id/asesors/:asesor_id", c.UnassignAsesor)
+	}
+}
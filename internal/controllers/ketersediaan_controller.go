@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultSlotRangeDays = 14
+
+type KetersediaanController struct {
+	ketersediaanService services.KetersediaanService
+	asesorService       services.AsesorService
+	calendarService     services.AsesorCalendarService
+}
+
+func NewKetersediaanController(
+	ketersediaanService services.KetersediaanService,
+	asesorService services.AsesorService,
+	calendarService services.AsesorCalendarService,
+) *KetersediaanController {
+	return &KetersediaanController{
+		ketersediaanService: ketersediaanService,
+		asesorService:       asesorService,
+		calendarService:     calendarService,
+	}
+}
+
+type KetersediaanRequest struct {
+	Jenis      string    `json:"jenis" binding:"required,oneof=tersedia tidak_tersedia"`
+	Mulai      time.Time `json:"mulai" binding:"required"`
+	Selesai    time.Time `json:"selesai" binding:"required"`
+	Keterangan string    `json:"keterangan" binding:"max=255"`
+}
+
+// parseDateRange reads the inclusive from and to dates of the query and
+// returns them as a half-open range.
+func parseDateRange(ctx *gin.Context) (time.Time, time.Time, bool) {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	from := today
+	if value := ctx.Query("from"); value != "" {
+		parsed, err := time.Parse(utils.DateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, defaultSlotRangeDays)
+	if value := ctx.Query("to"); value != "" {
+		parsed, err := time.Parse(utils.DateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	return from, to, true
+}
+
+func (c *KetersediaanController) GetKetersediaan(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	c.listKetersediaan(ctx, uint(id))
+}
+
+func (c *KetersediaanController) AddKetersediaan(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	c.addKetersediaan(ctx, uint(id))
+}
+
+func (c *KetersediaanController) DeleteKetersediaan(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	c.deleteKetersediaan(ctx, uint(id))
+}
+
+func (c *KetersediaanController) GetMyKetersediaan(ctx *gin.Context) {
+	asesor, ok := c.currentAsesor(ctx)
+	if !ok {
+		return
+	}
+
+	c.listKetersediaan(ctx, asesor.ID)
+}
+
+func (c *KetersediaanController) AddMyKetersediaan(ctx *gin.Context) {
+	asesor, ok := c.currentAsesor(ctx)
+	if !ok {
+		return
+	}
+
+	c.addKetersediaan(ctx, asesor.ID)
+}
+
+func (c *KetersediaanController) DeleteMyKetersediaan(ctx *gin.Context) {
+	asesor, ok := c.currentAsesor(ctx)
+	if !ok {
+		return
+	}
+
+	c.deleteKetersediaan(ctx, asesor.ID)
+}
+
+func (c *KetersediaanController) GetFreeSlots(ctx *gin.Context) {
+	kompetensiID, err := strconv.ParseUint(ctx.Query("kompetensi_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid kompetensi ID"))
+		return
+	}
+
+	from, to, ok := parseDateRange(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date range"))
+		return
+	}
+
+	slots, err := c.ketersediaanService.GetFreeSlots(ctx.Request.Context(), uint(kompetensiID), from, to)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Free slots retrieved successfully", slots))
+}
+
+func (c *KetersediaanController) GetMyCalendar(ctx *gin.Context) {
+	calendarURL, err := c.calendarService.GetCalendarURL(ctx.Request.Context(), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve calendar"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Calendar retrieved successfully", gin.H{"url": calendarURL}))
+}
+
+func (c *KetersediaanController) RotateMyCalendarToken(ctx *gin.Context) {
+	calendarURL, err := c.calendarService.RotateCalendarToken(ctx.Request.Context(), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to rotate calendar token"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Calendar token rotated successfully", gin.H{"url": calendarURL}))
+}
+
+func (c *KetersediaanController) GetCalendarFeed(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	feed, err := c.calendarService.RenderCalendar(ctx.Request.Context(), token)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Calendar not found"))
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
+
+func (c *KetersediaanController) currentAsesor(ctx *gin.Context) (*models.Asesor, bool) {
+	asesor, err := c.asesorService.GetAsesorByUserID(ctx.Request.Context(), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Asesor profile not found"))
+		return nil, false
+	}
+	return asesor, true
+}
+
+func (c *KetersediaanController) listKetersediaan(ctx *gin.Context, asesorID uint) {
+	from, to, ok := parseDateRange(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date range"))
+		return
+	}
+
+	ketersediaan, err := c.ketersediaanService.GetKetersediaan(ctx.Request.Context(), asesorID, from, to)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Ketersediaan retrieved successfully", ketersediaan))
+}
+
+func (c *KetersediaanController) addKetersediaan(ctx *gin.Context, asesorID uint) {
+	var req KetersediaanRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	ketersediaan, err := c.ketersediaanService.AddKetersediaan(ctx.Request.Context(), asesorID, req.Jenis, req.Mulai, req.Selesai, req.Keterangan)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Ketersediaan created successfully", ketersediaan))
+}
+
+func (c *KetersediaanController) deleteKetersediaan(ctx *gin.Context, asesorID uint) {
+	id, err := strconv.ParseUint(ctx.Param("ketersediaan_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid ketersediaan ID"))
+		return
+	}
+
+	err = c.ketersediaanService.DeleteKetersediaan(ctx.Request.Context(), asesorID, uint(id))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrKetersediaanNotFound) {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Ketersediaan deleted successfully", nil))
+}
+
+func (c *KetersediaanController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+
+	asesorRouter := router.Group("/asesors/:id/ketersediaan", authMiddleware, adminOnly)
+	{
+		asesorRouter.GET("", c.GetKetersediaan)
+		asesorRouter.POST("", c.AddKetersediaan)
+		asesorRouter.DELETE("/:ketersediaan_id", c.DeleteKetersediaan)
+	}
+
+	router.GET("/jadwal/free-slots", authMiddleware, adminOnly, c.GetFreeSlots)
+
+	meRouter := router.Group("/asesor/me", authMiddleware, middleware.RequireRole(models.RoleAsesor))
+	{
+		meRouter.GET("/ketersediaan", c.GetMyKetersediaan)
+		meRouter.POST("/ketersediaan", c.AddMyKetersediaan)
+		meRouter.DELETE("/ketersediaan/:ketersediaan_id", c.DeleteMyKetersediaan)
+		meRouter.GET("/calendar", c.GetMyCalendar)
+		meRouter.POST("/calendar/rotate", c.RotateMyCalendarToken)
+	}
+
+	// The feed is authorized by the secret token in its URL so calendar
+	// clients can subscribe without a bearer token
+	router.GET("/asesor/calendar/:token", c.GetCalendarFeed)
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KompetensiController struct {
+	kompetensiService services.KompetensiService
+}
+
+func NewKompetensiController(kompetensiService services.KompetensiService) *KompetensiController {
+	return &KompetensiController{
+		kompetensiService: kompetensiService,
+	}
+}
+
+type CreateUnitRequest struct {
+	KodeUnit  string          `json:"kode_unit" binding:"required,max=50"`
+	JudulUnit string          `json:"judul_unit" binding:"required,max=255"`
+	Urutan    int             `json:"urutan"`
+	Elemen    []ElemenRequest `json:"elemen" binding:"required,min=1,dive"`
+}
+
+type ElemenRequest struct {
+	Nama               string   `json:"nama" binding:"required,max=255"`
+	KriteriaUnjukKerja []string `json:"kriteria_unjuk_kerja" binding:"required,min=1,dive,required"`
+}
+
+func (r *CreateUnitRequest) toModel() *models.UnitKompetensi {
+	unit := &models.UnitKompetensi{
+		KodeUnit:  r.KodeUnit,
+		JudulUnit: r.JudulUnit,
+		Urutan:    r.Urutan,
+	}
+
+	for i, e := range r.Elemen {
+		elemen := models.Elemen{Nama: e.Nama, Urutan: i + 1}
+		for j, kuk := range e.KriteriaUnjukKerja {
+			elemen.KriteriaUnjukKerja = append(elemen.KriteriaUnjukKerja, models.KriteriaUnjukKerja{
+				Deskripsi: kuk,
+				Urutan:    j + 1,
+			})
+		}
+		unit.Elemen = append(unit.Elemen, elemen)
+	}
+
+	return unit
+}
+
+func (c *KompetensiController) GetAllKompetensi(ctx *gin.Context) {
+	kompetensi, err := c.kompetensiService.GetAllKompetensi(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve kompetensi"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Kompetensi retrieved successfully", kompetensi))
+}
+
+func (c *KompetensiController) GetKompetensi(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid kompetensi ID"))
+		return
+	}
+
+	kompetensi, err := c.kompetensiService.GetKompetensiByID(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Kompetensi not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Kompetensi retrieved successfully", kompetensi))
+}
+
+func (c *KompetensiController) CreateUnit(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid kompetensi ID"))
+		return
+	}
+
+	var req CreateUnitRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	unit, err := c.kompetensiService.CreateUnit(ctx.Request.Context(), uint(id), req.toModel())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Unit kompetensi created successfully", unit))
+}
+
+func (c *KompetensiController) GetUnits(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid kompetensi ID"))
+		return
+	}
+
+	units, err := c.kompetensiService.GetUnits(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Kompetensi not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Unit kompetensi retrieved successfully", units))
+}
+
+func (c *KompetensiController) DeleteUnit(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid kompetensi ID"))
+		return
+	}
+
+	unitID, err := strconv.ParseUint(ctx.Param("unit_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid unit kompetensi ID"))
+		return
+	}
+
+	err = c.kompetensiService.DeleteUnit(ctx.Request.Context(), uint(id), uint(unitID))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrUnitInUse) {
+			status = http.StatusConflict
+		}
+		ctx.JSON(status, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Unit kompetensi deleted successfully", nil))
+}
+
+func (c *KompetensiController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+
+	kompetensiRouter := router.Group("/kompetensi", authMiddleware)
+	{
+		kompetensiRouter.GET("/", c.GetAllKompetensi)
+		kompetensiRouter.GET("/:id", c.GetKompetensi)
+		kompetensiRouter.GET("/:id/units", c.GetUnits)
+		kompetensiRouter.POST("/:id/units", adminOnly, c.CreateUnit)
+		kompetensiRouter.DELETE("/:id/units/:unit_id", adminOnly, c.DeleteUnit)
+	}
+}
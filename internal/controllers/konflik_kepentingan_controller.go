@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KonflikKepentinganController struct {
+	konflikService services.KonflikKepentinganService
+}
+
+func NewKonflikKepentinganController(konflikService services.KonflikKepentinganService) *KonflikKepentinganController {
+	return &KonflikKepentinganController{
+		konflikService: konflikService,
+	}
+}
+
+type DeclareKonflikRequest struct {
+	AsesiID    uint   `json:"asesi_id" binding:"required"`
+	Jenis      string `json:"jenis" binding:"required,oneof=pekerjaan keluarga lainnya"`
+	Keterangan string `json:"keterangan" binding:"max=255"`
+}
+
+type RecordKonflikRequest struct {
+	AsesorID uint `json:"asesor_id" binding:"required"`
+	DeclareKonflikRequest
+}
+
+// parseUintQuery reads an optional numeric filter, treating an absent
+// value as zero.
+func parseUintQuery(ctx *gin.Context, key string) (uint, bool) {
+	value := ctx.Query(key)
+	if value == "" {
+		return 0, true
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(parsed), true
+}
+
+func (c *KonflikKepentinganController) GetKonflikList(ctx *gin.Context) {
+	asesorID, ok := parseUintQuery(ctx, "asesor_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesor ID"))
+		return
+	}
+
+	asesiID, ok := parseUintQuery(ctx, "asesi_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid asesi ID"))
+		return
+	}
+
+	konflik, err := c.konflikService.GetKonflikList(ctx.Request.Context(), asesorID, asesiID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve konflik kepentingan"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Konflik kepentingan retrieved successfully", konflik))
+}
+
+func (c *KonflikKepentinganController) RecordKonflik(ctx *gin.Context) {
+	var req RecordKonflikRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	konflik, err := c.konflikService.RecordKonflik(ctx.Request.Context(), ctx.GetUint("userID"), req.AsesorID, req.AsesiID, req.Jenis, req.Keterangan)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Konflik kepentingan recorded successfully", konflik))
+}
+
+func (c *KonflikKepentinganController) DeleteKonflik(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid konflik kepentingan ID"))
+		return
+	}
+
+	err = c.konflikService.DeleteKonflik(ctx.Request.Context(), uint(id))
+	if errors.Is(err, services.ErrKonflikNotFound) {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse(err.Error()))
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to delete konflik kepentingan"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Konflik kepentingan deleted successfully", nil))
+}
+
+func (c *KonflikKepentinganController) GetMyKonflik(ctx *gin.Context) {
+	konflik, err := c.konflikService.GetOwnKonflik(ctx.Request.Context(), ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Konflik kepentingan retrieved successfully", konflik))
+}
+
+func (c *KonflikKepentinganController) DeclareKonflik(ctx *gin.Context) {
+	var req DeclareKonflikRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	konflik, err := c.konflikService.DeclareKonflik(ctx.Request.Context(), ctx.GetUint("userID"), req.AsesiID, req.Jenis, req.Keterangan)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Konflik kepentingan declared successfully", konflik))
+}
+
+func (c *KonflikKepentinganController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	konflikRouter := router.Group("/konflik-kepentingan", authMiddleware, middleware.RequireRole(models.RoleAdmin))
+	{
+		konflikRouter.GET("/", c.GetKonflikList)
+		konflikRouter.POST("/", c.RecordKonflik)
+		konflikRouter.DELETE("/:id", c.DeleteKonflik)
+	}
+
+	meRouter := router.Group("/asesor/me", authMiddleware, middleware.RequireRole(models.RoleAsesor))
+	{
+		meRouter.GET("/konflik-kepentingan", c.GetMyKonflik)
+		meRouter.POST("/konflik-kepentingan", c.DeclareKonflik)
+	}
+}
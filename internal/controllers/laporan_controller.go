@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"lsp-api/internal/bnsp"
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LaporanController struct {
+	laporanService services.LaporanService
+}
+
+func NewLaporanController(laporanService services.LaporanService) *LaporanController {
+	return &LaporanController{
+		laporanService: laporanService,
+	}
+}
+
+// parseLaporanRange reads the inclusive from and to dates of a report and
+// returns them as a half-open range. Without dates the report covers the
+// current year up to today.
+func parseLaporanRange(ctx *gin.Context) (time.Time, time.Time, bool) {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	from := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, today.Location())
+	if value := ctx.Query("from"); value != "" {
+		parsed, err := time.Parse(utils.DateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+
+	to := today.AddDate(0, 0, 1)
+	if value := ctx.Query("to"); value != "" {
+		parsed, err := time.Parse(utils.DateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	return from, to, true
+}
+
+func (c *LaporanController) GetStatistikAsesor(ctx *gin.Context) {
+	from, to, ok := parseLaporanRange(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date range"))
+		return
+	}
+
+	statistik, err := c.laporanService.GetStatistikAsesor(ctx.Request.Context(), from, to, ctx.Query("periode"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Asesor statistics retrieved successfully", statistik))
+}
+
+func (c *LaporanController) GetStatistikKompetensi(ctx *gin.Context) {
+	from, to, ok := parseLaporanRange(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date range"))
+		return
+	}
+
+	statistik, err := c.laporanService.GetStatistikKompetensi(ctx.Request.Context(), from, to)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Kompetensi statistics retrieved successfully", statistik))
+}
+
+func (c *LaporanController) GetAsesorIdle(ctx *gin.Context) {
+	from, to, ok := parseLaporanRange(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date range"))
+		return
+	}
+
+	idle, err := c.laporanService.GetAsesorIdle(ctx.Request.Context(), from, to)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Idle asesors retrieved successfully", idle))
+}
+
+func (c *LaporanController) ValidateBNSP(ctx *gin.Context) {
+	from, to, ok := parseLaporanRange(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date range"))
+		return
+	}
+
+	masalah, err := c.laporanService.ValidateBNSP(ctx.Request.Context(), from, to)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("BNSP report validated successfully", gin.H{
+		"valid":   len(masalah) == 0,
+		"masalah": masalah,
+	}))
+}
+
+func (c *LaporanController) ExportBNSP(ctx *gin.Context) {
+	from, to, ok := parseLaporanRange(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date range"))
+		return
+	}
+
+	format := ctx.DefaultQuery("format", bnsp.FormatXLSX)
+
+	data, masalah, err := c.laporanService.ExportBNSP(ctx.Request.Context(), from, to, format)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	if len(masalah) > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, utils.Response{
+			Success: false,
+			Error:   "Mandatory BNSP fields are missing",
+			Data:    masalah,
+		})
+		return
+	}
+
+	contentType := "text/csv"
+	if format == bnsp.FormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	// The range is half-open, so the last included day is the one before to
+	filename := fmt.Sprintf("laporan-bnsp-%s-%s.%s", from.Format(utils.DateLayout), to.AddDate(0, 0, -1).Format(utils.DateLayout), format)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func (c *LaporanController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	laporanRouter := router.Group("/laporan", authMiddleware, middleware.RequireRole(models.RoleAdmin))
+	{
+		laporanRouter.GET("/asesor", c.GetStatistikAsesor)
+		laporanRouter.GET("/asesor/idle", c.GetAsesorIdle)
+		laporanRouter.GET("/kompetensi", c.GetStatistikKompetensi)
+		laporanRouter.GET("/bnsp", c.ExportBNSP)
+		laporanRouter.GET("/bnsp/validasi", c.ValidateBNSP)
+	}
+}
@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PenugasanController struct {
+	penugasanService services.PenugasanService
+}
+
+func NewPenugasanController(penugasanService services.PenugasanService) *PenugasanController {
+	return &PenugasanController{
+		penugasanService: penugasanService,
+	}
+}
+
+type ProposePenugasanRequest struct {
+	APL01IDs           []uint                  `json:"apl01_ids" binding:"required,min=1"`
+	MaksAsesiPerAsesor int                     `json:"maks_asesi_per_asesor" binding:"omitempty,min=1"`
+	Pengecualian       []services.Pengecualian `json:"pengecualian"`
+}
+
+type UpdateUsulanItemRequest struct {
+	AsesorID *uint `json:"asesor_id"`
+}
+
+func penugasanErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUsulanNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrUsulanAccepted):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrAsesorLicenseInvalid), errors.Is(err, services.ErrAsesorNotQualified),
+		errors.Is(err, services.ErrAsesorNotAvailable), errors.Is(err, services.ErrAsesorRatioReached),
+		errors.Is(err, services.ErrConflictOfInterest), errors.Is(err, services.ErrTUKCapacityExceeded):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func parseUsulanIDs(ctx *gin.Context) (uint, uint, bool) {
+	jadwalID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid jadwal ID"))
+		return 0, 0, false
+	}
+
+	usulanID, err := strconv.ParseUint(ctx.Param("usulan_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid usulan penugasan ID"))
+		return 0, 0, false
+	}
+
+	return uint(jadwalID), uint(usulanID), true
+}
+
+func (c *PenugasanController) ProposePenugasan(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid jadwal ID"))
+		return
+	}
+
+	var req ProposePenugasanRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	usulan, err := c.penugasanService.ProposePenugasan(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.APL01IDs, req.MaksAsesiPerAsesor, req.Pengecualian)
+	if err != nil {
+		ctx.JSON(penugasanErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Usulan penugasan created successfully", usulan))
+}
+
+func (c *PenugasanController) GetUsulanList(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid jadwal ID"))
+		return
+	}
+
+	usulan, err := c.penugasanService.GetUsulanList(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve usulan penugasan"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Usulan penugasan retrieved successfully", usulan))
+}
+
+func (c *PenugasanController) GetUsulan(ctx *gin.Context) {
+	jadwalID, usulanID, ok := parseUsulanIDs(ctx)
+	if !ok {
+		return
+	}
+
+	usulan, err := c.penugasanService.GetUsulan(ctx.Request.Context(), jadwalID, usulanID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Usulan penugasan not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Usulan penugasan retrieved successfully", usulan))
+}
+
+func (c *PenugasanController) UpdateUsulanItem(ctx *gin.Context) {
+	jadwalID, usulanID, ok := parseUsulanIDs(ctx)
+	if !ok {
+		return
+	}
+
+	itemID, err := strconv.ParseUint(ctx.Param("item_id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid item ID"))
+		return
+	}
+
+	var req UpdateUsulanItemRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	usulan, err := c.penugasanService.UpdateUsulanItem(ctx.Request.Context(), jadwalID, usulanID, uint(itemID), req.AsesorID)
+	if err != nil {
+		ctx.JSON(penugasanErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Usulan penugasan updated successfully", usulan))
+}
+
+func (c *PenugasanController) AcceptUsulan(ctx *gin.Context) {
+	jadwalID, usulanID, ok := parseUsulanIDs(ctx)
+	if !ok {
+		return
+	}
+
+	usulan, err := c.penugasanService.AcceptUsulan(ctx.Request.Context(), jadwalID, usulanID, ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(penugasanErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Usulan penugasan accepted successfully", usulan))
+}
+
+func (c *PenugasanController) GetPeserta(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid jadwal ID"))
+		return
+	}
+
+	peserta, err := c.penugasanService.GetPeserta(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve peserta"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Peserta retrieved successfully", peserta))
+}
+
+func (c *PenugasanController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	jadwalRouter := router.Group("/jadwal/:id", authMiddleware, middleware.RequireRole(models.RoleAdmin))
+	{
+		jadwalRouter.GET("/peserta", c.GetPeserta)
+		jadwalRouter.POST("/penugasan", c.ProposePenugasan)
+		jadwalRouter.GET("/penugasan", c.GetUsulanList)
+		jadwalRouter.GET("/penugasan/:usulan_id", c.GetUsulan)
+		jadwalRouter.PUT("/penugasan/:usulan_id/items/:item_id", c.UpdateUsulanItem)
+		jadwalRouter.POST("/penugasan/:usulan_id/accept", c.AcceptUsulan)
+	}
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SertifikatController struct {
+	sertifikatService services.SertifikatService
+}
+
+func NewSertifikatController(sertifikatService services.SertifikatService) *SertifikatController {
+	return &SertifikatController{
+		sertifikatService: sertifikatService,
+	}
+}
+
+type IssueSertifikatRequest struct {
+	HasilAsesmenID uint `json:"hasil_asesmen_id" binding:"required"`
+}
+
+type ChangeSertifikatStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=aktif dibekukan dicabut"`
+	Alasan string `json:"alasan" binding:"required"`
+}
+
+type RenewSertifikatRequest struct {
+	HasilAsesmenID *uint  `json:"hasil_asesmen_id"`
+	Alasan         string `json:"alasan"`
+}
+
+func sertifikatErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrSertifikatNotFound), errors.Is(err, services.ErrHasilAsesmenNotFound), errors.Is(err, services.ErrAPL01NotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrSertifikatAlreadyIssued), errors.Is(err, services.ErrSertifikatNotRenewable), errors.Is(err, services.ErrInvalidStatusTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (c *SertifikatController) IssueSertifikat(ctx *gin.Context) {
+	var req IssueSertifikatRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	sertifikat, err := c.sertifikatService.IssueSertifikat(ctx.Request.Context(), req.HasilAsesmenID, ctx.GetUint("userID"))
+	if err != nil {
+		ctx.JSON(sertifikatErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Sertifikat issued successfully", sertifikat))
+}
+
+func (c *SertifikatController) ChangeStatus(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid sertifikat ID"))
+		return
+	}
+
+	var req ChangeSertifikatStatusRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	sertifikat, err := c.sertifikatService.ChangeStatus(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.Status, req.Alasan)
+	if err != nil {
+		ctx.JSON(sertifikatErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Sertifikat status changed successfully", sertifikat))
+}
+
+func (c *SertifikatController) RenewSertifikat(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid sertifikat ID"))
+		return
+	}
+
+	var req RenewSertifikatRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	sertifikat, err := c.sertifikatService.RenewSertifikat(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), req.HasilAsesmenID, req.Alasan)
+	if err != nil {
+		ctx.JSON(sertifikatErrorStatus(err), utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("Sertifikat renewed successfully", sertifikat))
+}
+
+func (c *SertifikatController) GetSertifikat(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid sertifikat ID"))
+		return
+	}
+
+	sertifikat, err := c.sertifikatService.GetSertifikatByID(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), ctx.GetString("role"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Sertifikat not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Sertifikat retrieved successfully", sertifikat))
+}
+
+func (c *SertifikatController) GetAllSertifikat(ctx *gin.Context) {
+	sertifikat, err := c.sertifikatService.GetSertifikatList(ctx.Request.Context(), ctx.GetUint("userID"), ctx.GetString("role"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve sertifikat"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Sertifikat retrieved successfully", sertifikat))
+}
+
+func (c *SertifikatController) DownloadPDF(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid sertifikat ID"))
+		return
+	}
+
+	sertifikat, err := c.sertifikatService.GetSertifikatByID(ctx.Request.Context(), uint(id), ctx.GetUint("userID"), ctx.GetString("role"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Sertifikat not found"))
+		return
+	}
+
+	pdf, err := c.sertifikatService.RenderPDF(ctx.Request.Context(), sertifikat)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to render sertifikat"))
+		return
+	}
+
+	filename := fmt.Sprintf("sertifikat-%s.pdf", strings.ToLower(sertifikat.NomorSertifikat))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+func (c *SertifikatController) VerifySertifikat(ctx *gin.Context) {
+	verification, err := c.sertifikatService.VerifySertifikat(ctx.Request.Context(), ctx.Param("nomor_sertifikat"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("Sertifikat not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("Sertifikat verified successfully", verification))
+}
+
+func (c *SertifikatController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+
+	sertifikatRouter := router.Group("/sertifikat", authMiddleware)
+	{
+		sertifikatRouter.GET("/", c.GetAllSertifikat)
+		sertifikatRouter.GET("/:id", c.GetSertifikat)
+		sertifikatRouter.GET("/:id/pdf", c.DownloadPDF)
+		sertifikatRouter.POST("/", adminOnly, c.IssueSertifikat)
+		sertifikatRouter.POST("/:id/status", adminOnly, c.ChangeStatus)
+		sertifikatRouter.POST("/:id/renew", adminOnly, c.RenewSertifikat)
+	}
+}
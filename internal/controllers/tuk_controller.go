@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"lsp-api/internal/middleware"
+	"lsp-api/internal/models"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TUKController struct {
+	tukService services.TUKService
+}
+
+func NewTUKController(tukService services.TUKService) *TUKController {
+	return &TUKController{
+		tukService: tukService,
+	}
+}
+
+type TUKRequest struct {
+	Kode              string `json:"kode" binding:"required,max=50"`
+	Nama              string `json:"nama" binding:"required,max=150"`
+	Jenis             string `json:"jenis" binding:"required,oneof=sewaktu tempat_kerja mandiri"`
+	Alamat            string `json:"alamat" binding:"required"`
+	Kapasitas         int    `json:"kapasitas" binding:"required,min=1"`
+	Fasilitas         string `json:"fasilitas"`
+	StatusVerifikasi  string `json:"status_verifikasi" binding:"required,oneof=menunggu terverifikasi ditolak"`
+	TanggalVerifikasi string `json:"tanggal_verifikasi" binding:"omitempty,datetime=2006-01-02"`
+	BerlakuSampai     string `json:"berlaku_sampai" binding:"omitempty,datetime=2006-01-02"`
+	KompetensiID      []uint `json:"kompetensi_id" binding:"required,min=1"`
+}
+
+func (r *TUKRequest) toModel() *models.TUK {
+	return &models.TUK{
+		Kode:              r.Kode,
+		Nama:              r.Nama,
+		Jenis:             r.Jenis,
+		Alamat:            r.Alamat,
+		Kapasitas:         r.Kapasitas,
+		Fasilitas:         r.Fasilitas,
+		StatusVerifikasi:  r.StatusVerifikasi,
+		TanggalVerifikasi: parseOptionalDate(r.TanggalVerifikasi),
+		BerlakuSampai:     parseOptionalDate(r.BerlakuSampai),
+	}
+}
+
+// parseOptionalDate parses a date already checked by the datetime binding.
+func parseOptionalDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	date, err := time.Parse(utils.DateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+func (c *TUKController) CreateTUK(ctx *gin.Context) {
+	var req TUKRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	tuk, err := c.tukService.CreateTUK(ctx.Request.Context(), req.toModel(), req.KompetensiID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessResponse("TUK created successfully", tuk))
+}
+
+func (c *TUKController) UpdateTUK(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid TUK ID"))
+		return
+	}
+
+	var req TUKRequest
+
+	valid, _ := utils.ValidateRequest(ctx, &req)
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Validation failed"))
+		return
+	}
+
+	tuk, err := c.tukService.UpdateTUK(ctx.Request.Context(), uint(id), req.toModel(), req.KompetensiID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("TUK updated successfully", tuk))
+}
+
+func (c *TUKController) DeleteTUK(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid TUK ID"))
+		return
+	}
+
+	err = c.tukService.DeleteTUK(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("TUK deleted successfully", nil))
+}
+
+func (c *TUKController) GetTUK(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid TUK ID"))
+		return
+	}
+
+	tuk, err := c.tukService.GetTUKByID(ctx.Request.Context(), uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.ErrorResponse("TUK not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("TUK retrieved successfully", tuk))
+}
+
+func (c *TUKController) GetAllTUK(ctx *gin.Context) {
+	tuk, err := c.tukService.GetAllTUK(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to retrieve TUK"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessResponse("TUK retrieved successfully", tuk))
+}
+
+func (c *TUKController) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+
+	tukRouter := router.Group("/tuk", authMiddleware)
+	{
+		tukRouter.GET("/", c.GetAllTUK)
+		tukRouter.GET("/:id", c.GetTUK)
+		tukRouter.POST("/", adminOnly, c.CreateTUK)
+		tukRouter.PUT("/:id", adminOnly, c.UpdateTUK)
+		tukRouter.DELETE("/:id", adminOnly, c.DeleteTUK)
+	}
+}
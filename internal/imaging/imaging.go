@@ -0,0 +1,115 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+var ErrInvalidDimensions = errors.New("image dimensions are outside the allowed range")
+
+// Bounds describes the accepted pixel range of a source image.
+type Bounds struct {
+	MinWidth  int
+	MinHeight int
+	MaxWidth  int
+	MaxHeight int
+}
+
+// Decode reads a JPEG or PNG image after checking its header dimensions, so
+// oversized images are rejected before their pixels are allocated.
+func Decode(data []byte, bounds Bounds) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image: %w", err)
+	}
+
+	if format != FormatJPEG && format != FormatPNG {
+		return nil, "", fmt.Errorf("unsupported image format: %s", format)
+	}
+
+	if cfg.Width < bounds.MinWidth || cfg.Height < bounds.MinHeight ||
+		cfg.Width > bounds.MaxWidth || cfg.Height > bounds.MaxHeight {
+		return nil, "", fmt.Errorf("%w: got %dx%d, need between %dx%d and %dx%d", ErrInvalidDimensions,
+			cfg.Width, cfg.Height, bounds.MinWidth, bounds.MinHeight, bounds.MaxWidth, bounds.MaxHeight)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	return img, format, nil
+}
+
+// Cover crops the image around its center to the target aspect ratio and
+// scales it to exactly width x height on a white background.
+func Cover(src image.Image, width, height int) image.Image {
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	crop := b
+	if srcW*height > srcH*width {
+		cropW := srcH * width / height
+		x0 := b.Min.X + (srcW-cropW)/2
+		crop = image.Rect(x0, b.Min.Y, x0+cropW, b.Max.Y)
+	} else {
+		cropH := srcW * height / width
+		y0 := b.Min.Y + (srcH-cropH)/2
+		crop = image.Rect(b.Min.X, y0, b.Max.X, y0+cropH)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+	return dst
+}
+
+// Fit scales the image down to fit within maxWidth x maxHeight while keeping
+// its aspect ratio. Smaller images are returned at their original size.
+func Fit(src image.Image, maxWidth, maxHeight int) image.Image {
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	width, height := srcW, srcH
+	if width > maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+	}
+	if height > maxHeight {
+		width = width * maxHeight / height
+		height = maxHeight
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 90})
+	case FormatPNG:
+		return png.Encode(w, img)
+	default:
+		return fmt.Errorf("unsupported image format: %s", format)
+	}
+}
@@ -0,0 +1,329 @@
+package jwtkeys
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+const (
+	rsaKeyBits = 2048
+
+	// A key ID is its creation time followed by a random suffix, so keys
+	// written by several instances sort by age and never collide.
+	kidTimeFormat = "20060102T150405Z"
+
+	// reloadInterval bounds how often an unknown key ID may trigger a reload
+	// of the key directory.
+	reloadInterval = 10 * time.Second
+
+	// refreshInterval is how often Run refreshes the set. Other instances may
+	// keep signing with a replaced key until their next refresh, so replaced
+	// keys verify for retireGrace longer than the token lifetime.
+	refreshInterval = time.Minute
+	retireGrace     = 5 * refreshInterval
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Key is a private signing key stored as <ID>.pem in the key directory.
+type Key struct {
+	ID        string
+	Algorithm string
+	Created   time.Time
+
+	private any
+	public  crypto.PublicKey
+}
+
+// Method returns the JWT signing method of the key.
+func (k *Key) Method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+func (k *Key) PrivateKey() any {
+	return k.private
+}
+
+func (k *Key) PublicKey() crypto.PublicKey {
+	return k.public
+}
+
+type Options struct {
+	Dir string
+
+	// Algorithm is used for keys generated from now on. Existing keys keep
+	// the algorithm of their key type.
+	Algorithm string
+
+	// RotationInterval is the age at which the signing key is replaced, zero
+	// disables rotation. TokenLifetime is how long a replaced key keeps
+	// verifying, so tokens it signed stay valid until they expire.
+	RotationInterval time.Duration
+	TokenLifetime    time.Duration
+}
+
+// KeySet signs with its newest key and verifies with every key that may
+// still have signed an unexpired token. The directory may be shared by
+// several API instances: they pick up each other's keys when reloading.
+type KeySet struct {
+	opts Options
+
+	mu         sync.RWMutex
+	keys       []*Key
+	lastReload time.Time
+}
+
+// Load reads the key directory, generating the first key when it is empty
+// and a new one when the signing key is due for rotation.
+func Load(opts Options) (*KeySet, error) {
+	if opts.Algorithm != AlgorithmRS256 && opts.Algorithm != AlgorithmEdDSA {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", opts.Algorithm)
+	}
+
+	err := os.MkdirAll(opts.Dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %w", err)
+	}
+
+	s := &KeySet{opts: opts}
+	err = s.Refresh()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (s *KeySet) SigningKey() *Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.keys[len(s.keys)-1]
+}
+
+// VerificationKey returns the key with the given ID while tokens signed by
+// it may still be valid. An unknown ID reloads the directory first, in case
+// another instance has just rotated.
+func (s *KeySet) VerificationKey(kid string) (*Key, error) {
+	key := s.find(kid)
+	if key == nil && s.reloadDue() {
+		err := s.reload()
+		if err != nil {
+			return nil, err
+		}
+		key = s.find(kid)
+	}
+	if key == nil {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// Keys returns the keys that currently verify tokens, oldest first.
+func (s *KeySet) Keys() []*Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]*Key(nil), s.keys...)
+}
+
+// Refresh reloads the directory, rotates the signing key when it is due and
+// deletes keys whose tokens have all expired.
+func (s *KeySet) Refresh() error {
+	err := s.reload()
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	due := len(s.keys) == 0 ||
+		(s.opts.RotationInterval > 0 && time.Since(s.keys[len(s.keys)-1].Created) >= s.opts.RotationInterval)
+	s.mu.RUnlock()
+
+	if due {
+		key, err := s.generate()
+		if err != nil {
+			return err
+		}
+		slog.Info("generated JWT signing key", slog.String("kid", key.ID), slog.String("alg", key.Algorithm))
+		return s.reload()
+	}
+
+	return nil
+}
+
+// Run refreshes the key set periodically until ctx is done.
+func (s *KeySet) Run(ctx context.Context) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Refresh()
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to refresh JWT keys", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+func (s *KeySet) find(kid string) *Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.ID == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+func (s *KeySet) reloadDue() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return time.Since(s.lastReload) >= reloadInterval
+}
+
+// reload reads every key of the directory and keeps those still verifying.
+// A key is retired once a newer key exists and is deleted one token lifetime
+// (plus grace) after that.
+func (s *KeySet) reload() error {
+	paths, err := filepath.Glob(filepath.Join(s.opts.Dir, "*.pem"))
+	if err != nil {
+		return fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	var keys []*Key
+	for _, path := range paths {
+		key, err := readKey(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Another instance retired the key after it was listed
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	now := time.Now()
+	active := keys[:0]
+	for i, key := range keys {
+		if i+1 < len(keys) && now.Sub(keys[i+1].Created) >= s.opts.TokenLifetime+retireGrace {
+			err = os.Remove(filepath.Join(s.opts.Dir, key.ID+".pem"))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to delete expired key %s: %w", key.ID, err)
+			}
+			continue
+		}
+		active = append(active, key)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = active
+	s.lastReload = now
+	return nil
+}
+
+func (s *KeySet) generate() (*Key, error) {
+	var private any
+	var err error
+	switch s.opts.Algorithm {
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	kid := time.Now().UTC().Format(kidTimeFormat) + "-" + hex.EncodeToString(suffix)
+
+	// Write under a temporary name so other instances never read half a key
+	path := filepath.Join(s.opts.Dir, kid+".pem")
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write key: %w", err)
+	}
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write key: %w", err)
+	}
+
+	return readKey(path)
+}
+
+func readKey(path string) (*Key, error) {
+	kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+	created, err := time.Parse(kidTimeFormat, strings.SplitN(kid, "-", 2)[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file name %s: %w", filepath.Base(path), err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %s: %w", kid, err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("key %s is not a PEM encoded PKCS#8 private key", kid)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %s: %w", kid, err)
+	}
+
+	key := &Key{ID: kid, Created: created, private: private}
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		key.Algorithm = AlgorithmRS256
+		key.public = &private.PublicKey
+	case ed25519.PrivateKey:
+		key.Algorithm = AlgorithmEdDSA
+		key.public = private.Public()
+	default:
+		return nil, fmt.Errorf("key %s has an unsupported type %T", kid, private)
+	}
+
+	return key, nil
+}
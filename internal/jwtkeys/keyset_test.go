@@ -0,0 +1,226 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeKey stores an Ed25519 key created at the given time in dir and
+// returns its key ID.
+func writeKey(t *testing.T, dir string, created time.Time) string {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kid := created.UTC().Format(kidTimeFormat) + "-test"
+	err = os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kid
+}
+
+func keyIDs(s *KeySet) []string {
+	var ids []string
+	for _, key := range s.Keys() {
+		ids = append(ids, key.ID)
+	}
+	return ids
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		wantErr   bool
+	}{
+		{AlgorithmRS256, false},
+		{AlgorithmEdDSA, false},
+		{"HS256", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		s, err := Load(Options{Dir: t.TempDir(), Algorithm: tt.algorithm, TokenLifetime: time.Hour})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Load(%q) succeeded, want error", tt.algorithm)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Load(%q): %v", tt.algorithm, err)
+			continue
+		}
+
+		keys := s.Keys()
+		if len(keys) != 1 {
+			t.Errorf("Load(%q) generated %d keys, want 1", tt.algorithm, len(keys))
+			continue
+		}
+		if keys[0].Algorithm != tt.algorithm || keys[0].Method().Alg() != tt.algorithm {
+			t.Errorf("Load(%q) generated a %s key", tt.algorithm, keys[0].Algorithm)
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		age      time.Duration
+		rotation time.Duration
+		rotated  bool
+	}{
+		{"young key", time.Hour, 24 * time.Hour, false},
+		{"key due", 25 * time.Hour, 24 * time.Hour, true},
+		{"rotation disabled", 1000 * time.Hour, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			old := writeKey(t, dir, time.Now().Add(-tt.age))
+
+			s, err := Load(Options{Dir: dir, Algorithm: AlgorithmEdDSA, RotationInterval: tt.rotation, TokenLifetime: 48 * time.Hour})
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			signing := s.SigningKey().ID
+			if rotated := signing != old; rotated != tt.rotated {
+				t.Fatalf("signing key %s, rotated = %v, want %v", signing, rotated, tt.rotated)
+			}
+			if _, err := s.VerificationKey(old); err != nil {
+				t.Errorf("replaced key no longer verifies: %v", err)
+			}
+		})
+	}
+}
+
+func TestRetirement(t *testing.T) {
+	const lifetime = time.Hour
+
+	tests := []struct {
+		name string
+		// replacedFor is how long ago the newer key replaced the old one
+		replacedFor time.Duration
+		retired     bool
+	}{
+		{"tokens may be unexpired", lifetime - time.Minute, false},
+		{"within grace", lifetime + retireGrace - time.Minute, false},
+		{"lifetime and grace elapsed", lifetime + retireGrace + time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			now := time.Now()
+			old := writeKey(t, dir, now.Add(-tt.replacedFor-24*time.Hour))
+			current := writeKey(t, dir, now.Add(-tt.replacedFor))
+
+			s, err := Load(Options{Dir: dir, Algorithm: AlgorithmEdDSA, TokenLifetime: lifetime})
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			if s.SigningKey().ID != current {
+				t.Errorf("signing key %s, want %s", s.SigningKey().ID, current)
+			}
+
+			_, err = s.VerificationKey(old)
+			if retired := errors.Is(err, ErrUnknownKey); retired != tt.retired {
+				t.Errorf("old key retired = %v, want %v (keys %v)", retired, tt.retired, keyIDs(s))
+			}
+
+			_, err = os.Stat(filepath.Join(dir, old+".pem"))
+			if deleted := errors.Is(err, os.ErrNotExist); deleted != tt.retired {
+				t.Errorf("old key file deleted = %v, want %v", deleted, tt.retired)
+			}
+		})
+	}
+}
+
+func TestVerificationKeyUnknown(t *testing.T) {
+	s, err := Load(Options{Dir: t.TempDir(), Algorithm: AlgorithmEdDSA, TokenLifetime: time.Hour})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	for _, kid := range []string{"", "20260101T000000Z-missing", "../secret"} {
+		_, err := s.VerificationKey(kid)
+		if !errors.Is(err, ErrUnknownKey) {
+			t.Errorf("VerificationKey(%q) = %v, want ErrUnknownKey", kid, err)
+		}
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		kty       string
+	}{
+		{AlgorithmRS256, "RSA"},
+		{AlgorithmEdDSA, "OKP"},
+	}
+
+	for _, tt := range tests {
+		s, err := Load(Options{Dir: t.TempDir(), Algorithm: tt.algorithm, TokenLifetime: time.Hour})
+		if err != nil {
+			t.Fatalf("Load(%q): %v", tt.algorithm, err)
+		}
+		key := s.SigningKey()
+
+		set := s.JWKS()
+		if len(set.Keys) != 1 {
+			t.Fatalf("%s: JWKS has %d keys, want 1", tt.algorithm, len(set.Keys))
+		}
+		jwk := set.Keys[0]
+		if jwk.Kty != tt.kty || jwk.Kid != key.ID || jwk.Alg != tt.algorithm || jwk.Use != "sig" {
+			t.Errorf("%s: JWK = %+v", tt.algorithm, jwk)
+		}
+
+		switch public := key.PublicKey().(type) {
+		case *rsa.PublicKey:
+			n, e := decodeBase64URL(t, jwk.N), decodeBase64URL(t, jwk.E)
+			if new(big.Int).SetBytes(n).Cmp(public.N) != 0 || new(big.Int).SetBytes(e).Int64() != int64(public.E) {
+				t.Errorf("RSA JWK does not encode the public key")
+			}
+			if jwk.Crv != "" || jwk.X != "" {
+				t.Errorf("RSA JWK sets OKP members: %+v", jwk)
+			}
+		case ed25519.PublicKey:
+			if jwk.Crv != "Ed25519" || !public.Equal(ed25519.PublicKey(decodeBase64URL(t, jwk.X))) {
+				t.Errorf("Ed25519 JWK does not encode the public key")
+			}
+			if jwk.N != "" || jwk.E != "" {
+				t.Errorf("Ed25519 JWK sets RSA members: %+v", jwk)
+			}
+		}
+	}
+}
+
+func decodeBase64URL(t *testing.T, value string) []byte {
+	t.Helper()
+
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("decoding %q: %v", value, err)
+	}
+	return b
+}
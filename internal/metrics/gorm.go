@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const queryStartKey = "metrics:query_start"
+
+type registerFunc func(name string, fn func(*gorm.DB)) error
+
+// InstrumentDB times every GORM operation and exposes the sql.DBStats of the
+// connection pool.
+func (m *Metrics) InstrumentDB(db *gorm.DB, dbName string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	err = m.registry.Register(collectors.NewDBStatsCollector(sqlDB, dbName))
+	if err != nil {
+		return fmt.Errorf("failed to register db stats collector: %w", err)
+	}
+
+	cb := db.Callback()
+	hooks := []struct {
+		operation     string
+		before, after registerFunc
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, hook := range hooks {
+		err = hook.before("metrics:before_"+hook.operation, startQuery)
+		if err != nil {
+			return fmt.Errorf("failed to register metrics callback: %w", err)
+		}
+		err = hook.after("metrics:after_"+hook.operation, m.observeQuery(hook.operation))
+		if err != nil {
+			return fmt.Errorf("failed to register metrics callback: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func startQuery(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func (m *Metrics) observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+
+		m.queryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+	}
+}
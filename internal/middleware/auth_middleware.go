@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"lsp-api/internal/logging"
 	"lsp-api/internal/services"
 	"lsp-api/internal/utils"
 
@@ -13,47 +14,75 @@ import (
 
 func AuthMiddleware(authService services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get Authorization header
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Authorization header is required"))
+		// The token was already accepted by Identify
+		if _, ok := c.Get("userID"); ok {
+			c.Next()
 			return
 		}
 
-		// Check if the header has the Bearer prefix
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Invalid authorization format, expected 'Bearer {token}'"))
+		message := authenticate(c, authService)
+		if message != "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse(message))
 			return
 		}
 
-		tokenString := parts[1]
+		c.Next()
+	}
+}
 
-		// Validate token
-		token, err := authService.ValidateToken(tokenString)
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Invalid or expired token"))
-			return
+// Identify sets the user of a request carrying a valid bearer token without
+// rejecting anything, so middleware running before the routes, such as the
+// rate limiter, can tell users apart. Routes still require AuthMiddleware.
+func Identify(authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			authenticate(c, authService)
 		}
 
-		// Extract claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Failed to parse token claims"))
-			return
-		}
+		c.Next()
+	}
+}
 
-		// Set user ID in context
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Invalid token claims"))
-			return
-		}
+// authenticate validates the bearer token and stores its claims on the
+// context. It returns the reason the request is unauthorized, if any.
+func authenticate(c *gin.Context, authService services.AuthService) string {
+	// Get Authorization header
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return "Authorization header is required"
+	}
+
+	// Check if the header has the Bearer prefix
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "Invalid authorization format, expected 'Bearer {token}'"
+	}
 
-		c.Set("userID", uint(userID))
-		c.Set("email", claims["email"])
-		c.Set("username", claims["username"])
+	tokenString := parts[1]
 
-		c.Next()
+	// Validate token
+	token, err := authService.ValidateToken(c.Request.Context(), tokenString)
+	if err != nil || !token.Valid {
+		return "Invalid or expired token"
 	}
+
+	// Extract claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "Failed to parse token claims"
+	}
+
+	// Set user ID in context
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return "Invalid token claims"
+	}
+
+	c.Set("userID", uint(userID))
+	c.Set("email", claims["email"])
+	c.Set("username", claims["username"])
+	c.Set("role", claims["role"])
+	c.Request = c.Request.WithContext(logging.WithUserID(c.Request.Context(), uint(userID)))
+
+	return ""
 }
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// corsExposedHeaders are the response headers of the API that browser
+// clients on another origin need to read.
+var corsExposedHeaders = []string{
+	RequestIDHeader,
+	"Content-Disposition",
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+}
+
+// CORS lets the listed origins call the API from a browser and answers their
+// preflight requests. "*" allows any origin but cannot be combined with
+// credentials. Without origins cross-origin requests stay blocked.
+func CORS(origins, methods, headers []string, allowCredentials bool) (gin.HandlerFunc, error) {
+	if len(origins) == 0 {
+		return func(c *gin.Context) { c.Next() }, nil
+	}
+
+	if slices.Contains(origins, "*") && allowCredentials {
+		return nil, errors.New("credentials cannot be allowed for every origin")
+	}
+
+	config := cors.Config{
+		AllowOrigins:     origins,
+		AllowMethods:     methods,
+		AllowHeaders:     headers,
+		ExposeHeaders:    corsExposedHeaders,
+		AllowCredentials: allowCredentials,
+		MaxAge:           12 * time.Hour,
+	}
+	if slices.Contains(origins, "*") {
+		config.AllowOrigins = nil
+		config.AllowAllOrigins = true
+	}
+
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return cors.New(config), nil
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	corsMethods = []string{"GET", "POST", "PUT", "DELETE"}
+	corsHeaders = []string{"Authorization", "Content-Type"}
+)
+
+func TestCORSConfiguration(t *testing.T) {
+	tests := []struct {
+		name        string
+		origins     []string
+		credentials bool
+		wantErr     bool
+	}{
+		{name: "no origins", origins: nil},
+		{name: "listed origins with credentials", origins: []string{"https://app.lsp.id"}, credentials: true},
+		{name: "any origin", origins: []string{"*"}},
+		{name: "any origin with credentials", origins: []string{"*"}, credentials: true, wantErr: true},
+		{name: "listed and any origin with credentials", origins: []string{"https://app.lsp.id", "*"}, credentials: true, wantErr: true},
+		{name: "origin without scheme", origins: []string{"app.lsp.id"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		_, err := CORS(tt.origins, corsMethods, corsHeaders, tt.credentials)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func serveCORS(t *testing.T, origins []string, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handler, err := CORS(origins, corsMethods, corsHeaders, true)
+	if err != nil {
+		t.Fatalf("CORS: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(RequestID(), handler)
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestCORSRequests(t *testing.T) {
+	origins := []string{"https://app.lsp.id"}
+
+	tests := []struct {
+		name       string
+		origins    []string
+		method     string
+		origin     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{name: "preflight from allowed origin", origins: origins, method: http.MethodOptions,
+			origin: "https://app.lsp.id", wantStatus: http.StatusNoContent, wantOrigin: "https://app.lsp.id"},
+		{name: "preflight from other origin", origins: origins, method: http.MethodOptions,
+			origin: "https://evil.example", wantStatus: http.StatusForbidden},
+		{name: "request from allowed origin", origins: origins, method: http.MethodGet,
+			origin: "https://app.lsp.id", wantStatus: http.StatusNoContent, wantOrigin: "https://app.lsp.id"},
+		{name: "request from other origin", origins: origins, method: http.MethodGet,
+			origin: "https://evil.example", wantStatus: http.StatusForbidden},
+		{name: "same origin request", origins: origins, method: http.MethodGet,
+			wantStatus: http.StatusNoContent},
+		{name: "cors disabled", method: http.MethodGet,
+			origin: "https://app.lsp.id", wantStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/ping", nil)
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		if tt.method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "Authorization")
+		}
+
+		recorder := serveCORS(t, tt.origins, req)
+		if recorder.Code != tt.wantStatus {
+			t.Errorf("%s: status %d, want %d", tt.name, recorder.Code, tt.wantStatus)
+		}
+		if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+			t.Errorf("%s: allowed origin %q, want %q", tt.name, got, tt.wantOrigin)
+		}
+		if tt.wantOrigin != "" && recorder.Header().Get("Access-Control-Allow-Credentials") != "true" {
+			t.Errorf("%s: credentials not allowed", tt.name)
+		}
+	}
+}
+
+func TestCORSExposesAPIHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Origin", "https://app.lsp.id")
+
+	recorder := serveCORS(t, []string{"https://app.lsp.id"}, req)
+
+	exposed := map[string]bool{}
+	for _, header := range strings.Split(recorder.Header().Get("Access-Control-Expose-Headers"), ",") {
+		exposed[http.CanonicalHeaderKey(strings.TrimSpace(header))] = true
+	}
+	for _, header := range []string{RequestIDHeader, "Content-Disposition", "Retry-After", "X-RateLimit-Remaining"} {
+		if !exposed[http.CanonicalHeaderKey(header)] {
+			t.Errorf("%s is not exposed to cross-origin clients", header)
+		}
+	}
+}
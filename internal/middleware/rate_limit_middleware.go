@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lsp-api/internal/ratelimit"
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit throttles each user identified by Identify with userLimit and
+// every other client by IP with anonymousLimit. A disabled limit lets its
+// requests through. Requests are allowed when the store fails, so an outage
+// of a shared store does not take the API down with it.
+func RateLimit(store ratelimit.Store, anonymousLimit, userLimit ratelimit.Limit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := anonymousLimit
+		key := "ip:" + c.ClientIP()
+		if userID, ok := c.Get("userID"); ok {
+			limit = userLimit
+			key = fmt.Sprintf("user:%d", userID)
+		}
+
+		if limit.Disabled() {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), key, limit)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "rate limit store failed", slog.String("error", err.Error()))
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", ceilSeconds(result.Reset))
+
+		if !result.Allowed {
+			c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, utils.ErrorResponse("Too many requests, please try again later"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
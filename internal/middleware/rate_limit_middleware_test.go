@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"lsp-api/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubStore returns a fixed result and records the keys it was asked for.
+type stubStore struct {
+	result ratelimit.Result
+	err    error
+	keys   []string
+}
+
+func (s *stubStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	s.keys = append(s.keys, key)
+	return s.result, s.err
+}
+
+func serveRateLimited(store ratelimit.Store, userID any) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != nil {
+			c.Set("userID", userID)
+		}
+	})
+	router.Use(RateLimit(store, ratelimit.PerMinute(30, 10), ratelimit.PerMinute(300, 60)))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     any
+		result     ratelimit.Result
+		err        error
+		wantKey    string
+		wantStatus int
+		wantHeader map[string]string
+	}{
+		{
+			name:       "anonymous allowed",
+			result:     ratelimit.Result{Allowed: true, Remaining: 9, Reset: 2 * time.Second},
+			wantKey:    "ip:10.0.0.1",
+			wantStatus: http.StatusNoContent,
+			wantHeader: map[string]string{
+				"X-RateLimit-Limit":     "10",
+				"X-RateLimit-Remaining": "9",
+				"X-RateLimit-Reset":     "2",
+				"Retry-After":           "",
+			},
+		},
+		{
+			name:       "user allowed",
+			userID:     uint(7),
+			result:     ratelimit.Result{Allowed: true, Remaining: 59, Reset: 200 * time.Millisecond},
+			wantKey:    "user:7",
+			wantStatus: http.StatusNoContent,
+			wantHeader: map[string]string{
+				"X-RateLimit-Limit":     "60",
+				"X-RateLimit-Remaining": "59",
+				"X-RateLimit-Reset":     "1",
+			},
+		},
+		{
+			name:       "throttled",
+			result:     ratelimit.Result{Remaining: 0, RetryAfter: 1500 * time.Millisecond, Reset: 20 * time.Second},
+			wantKey:    "ip:10.0.0.1",
+			wantStatus: http.StatusTooManyRequests,
+			wantHeader: map[string]string{
+				"X-RateLimit-Limit":     "10",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "20",
+				"Retry-After":           "2",
+			},
+		},
+		{
+			name:       "store failure lets the request through",
+			err:        errors.New("connection refused"),
+			wantKey:    "ip:10.0.0.1",
+			wantStatus: http.StatusNoContent,
+			wantHeader: map[string]string{
+				"X-RateLimit-Limit": "",
+				"Retry-After":       "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &stubStore{result: tt.result, err: tt.err}
+			recorder := serveRateLimited(store, tt.userID)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if len(store.keys) != 1 || store.keys[0] != tt.wantKey {
+				t.Errorf("store keys %v, want [%s]", store.keys, tt.wantKey)
+			}
+			for name, want := range tt.wantHeader {
+				if got := recorder.Header().Get(name); got != want {
+					t.Errorf("header %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitWithMemoryStore(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+
+	var recorder *httptest.ResponseRecorder
+	for i := 0; i < 10; i++ {
+		recorder = serveRateLimited(store, nil)
+		if recorder.Code != http.StatusNoContent {
+			t.Fatalf("request %d within the burst got status %d", i+1, recorder.Code)
+		}
+	}
+
+	recorder = serveRateLimited(store, nil)
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("request past the burst got status %d, want 429", recorder.Code)
+	}
+	// 30 per minute refills a token every two seconds
+	if got := recorder.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2", got)
+	}
+	if got := recorder.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 0", got)
+	}
+
+	// Users are limited separately from the address they connect from
+	recorder = serveRateLimited(store, uint(1))
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("authenticated request got status %d", recorder.Code)
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole must run after AuthMiddleware, which sets the role from the token claims.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, utils.ErrorResponse("You do not have permission to access this resource"))
+	}
+}
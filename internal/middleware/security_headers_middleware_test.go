@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxAge   time.Duration
+		wantHSTS string
+	}{
+		{name: "hsts on", maxAge: 180 * 24 * time.Hour, wantHSTS: "max-age=15552000; includeSubDomains"},
+		{name: "hsts off", maxAge: 0, wantHSTS: ""},
+	}
+
+	for _, tt := range tests {
+		gin.SetMode(gin.TestMode)
+
+		router := gin.New()
+		router.Use(SecurityHeaders(tt.maxAge))
+		router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		want := map[string]string{
+			"X-Content-Type-Options":    "nosniff",
+			"X-Frame-Options":           "DENY",
+			"Referrer-Policy":           "no-referrer",
+			"Content-Security-Policy":   apiContentSecurityPolicy,
+			"Strict-Transport-Security": tt.wantHSTS,
+		}
+		for header, value := range want {
+			if got := recorder.Header().Get(header); got != value {
+				t.Errorf("%s: %s = %q, want %q", tt.name, header, got, value)
+			}
+		}
+		if _, ok := recorder.Header()["Strict-Transport-Security"]; ok && tt.wantHSTS == "" {
+			t.Errorf("%s: HSTS header sent", tt.name)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"lsp-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout puts a deadline on the request context, so queries and storage
+// calls of a request are cancelled once it passes or the client disconnects.
+// A zero timeout leaves requests unbounded.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, utils.ErrorResponse("Request timed out"))
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusAPL01Draft       = "draft"
+	StatusAPL01Diajukan    = "diajukan"
+	StatusAPL01PerluRevisi = "perlu_revisi"
+	StatusAPL01Disetujui   = "disetujui"
+	StatusAPL01Ditolak     = "ditolak"
+)
+
+// apl01Transitions lists the statuses each APL-01 status may move to.
+var apl01Transitions = map[string][]string{
+	StatusAPL01Draft:       {StatusAPL01Diajukan},
+	StatusAPL01PerluRevisi: {StatusAPL01Diajukan},
+	StatusAPL01Diajukan:    {StatusAPL01PerluRevisi, StatusAPL01Disetujui, StatusAPL01Ditolak},
+}
+
+// APL01 is the FR.APL.01 permohonan sertifikasi submitted by an asesi.
+type APL01 struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	AsesiID      uint       `gorm:"not null;index" json:"asesi_id"`
+	Asesi        User       `json:"-"`
+	KompetensiID uint       `gorm:"not null;index" json:"kompetensi_id"`
+	Kompetensi   Kompetensi `json:"kompetensi"`
+
+	// Data pribadi
+	NamaLengkap        string     `gorm:"size:150;not null" json:"nama_lengkap"`
+	NIK                string     `gorm:"size:16;not null;index" json:"nik"`
+	TempatLahir        string     `gorm:"size:100" json:"tempat_lahir"`
+	TanggalLahir       *time.Time `json:"tanggal_lahir"`
+	JenisKelamin       string     `gorm:"size:1" json:"jenis_kelamin"`
+	Kebangsaan         string     `gorm:"size:50" json:"kebangsaan"`
+	AlamatRumah        string     `gorm:"type:text" json:"alamat_rumah"`
+	KodePos            string     `gorm:"size:10" json:"kode_pos"`
+	NoTelepon          string     `gorm:"size:20" json:"no_telepon"`
+	Email              string     `gorm:"size:100" json:"email"`
+	PendidikanTerakhir string     `gorm:"size:50" json:"pendidikan_terakhir"`
+
+	// Data pekerjaan
+	NamaInstitusi string `gorm:"size:150" json:"nama_institusi"`
+	Jabatan       string `gorm:"size:100" json:"jabatan"`
+	AlamatKantor  string `gorm:"type:text" json:"alamat_kantor"`
+	TeleponKantor string `gorm:"size:20" json:"telepon_kantor"`
+	EmailKantor   string `gorm:"size:100" json:"email_kantor"`
+
+	TujuanAsesmen string         `gorm:"size:30" json:"tujuan_asesmen"`
+	Status        string         `gorm:"size:20;not null;default:draft;index" json:"status"`
+	Catatan       string         `gorm:"type:text" json:"catatan"`
+	DiajukanPada  *time.Time     `json:"diajukan_pada"`
+	Dokumen       []APL01Dokumen `gorm:"foreignKey:APL01ID" json:"dokumen"`
+	Riwayat       []APL01Riwayat `gorm:"foreignKey:APL01ID" json:"riwayat"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (APL01) TableName() string {
+	return "apl01"
+}
+
+// Editable reports whether the asesi may still change the form.
+func (a *APL01) Editable() bool {
+	return a.Status == StatusAPL01Draft || a.Status == StatusAPL01PerluRevisi
+}
+
+func (a *APL01) CanTransitionTo(status string) bool {
+	for _, next := range apl01Transitions[a.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+type APL01Dokumen struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	APL01ID    uint      `gorm:"column:apl01_id;not null;uniqueIndex:idx_apl01_dokumen" json:"apl01_id"`
+	DokumenID  uint      `gorm:"not null;uniqueIndex:idx_apl01_dokumen" json:"dokumen_id"`
+	Dokumen    Dokumen   `json:"dokumen"`
+	Keterangan string    `gorm:"size:255" json:"keterangan"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (APL01Dokumen) TableName() string {
+	return "apl01_dokumen"
+}
+
+// APL01Riwayat records every status change of an APL-01 form.
+type APL01Riwayat struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	APL01ID    uint      `gorm:"column:apl01_id;not null;index" json:"apl01_id"`
+	DariStatus string    `gorm:"size:20" json:"dari_status"`
+	KeStatus   string    `gorm:"size:20;not null" json:"ke_status"`
+	Catatan    string    `gorm:"type:text" json:"catatan"`
+	OlehUserID uint      `gorm:"not null" json:"oleh_user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (APL01Riwayat) TableName() string {
+	return "apl01_riwayat"
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	StatusAPL02Draft    = "draft"
+	StatusAPL02Diajukan = "diajukan"
+	StatusAPL02Direview = "direview"
+
+	JawabanKompeten      = "K"
+	JawabanBelumKompeten = "BK"
+
+	RekomendasiLanjut      = "lanjut"
+	RekomendasiTidakLanjut = "tidak_lanjut"
+)
+
+// APL02 is the FR.APL.02 self-assessment an asesi fills in after their APL-01
+// is approved, reviewed by the assigned asesor.
+type APL02 struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	APL01ID       uint        `gorm:"column:apl01_id;not null;uniqueIndex" json:"apl01_id"`
+	AsesiID       uint        `gorm:"not null;index" json:"asesi_id"`
+	KompetensiID  uint        `gorm:"not null;index" json:"kompetensi_id"`
+	Kompetensi    Kompetensi  `json:"kompetensi"`
+	AsesorID      *uint       `gorm:"index" json:"asesor_id"`
+	Asesor        *Asesor     `json:"asesor,omitempty"`
+	Status        string      `gorm:"size:20;not null;default:draft;index" json:"status"`
+	Rekomendasi   string      `gorm:"size:20" json:"rekomendasi"`
+	CatatanAsesor string      `gorm:"type:text" json:"catatan_asesor"`
+	DiajukanPada  *time.Time  `json:"diajukan_pada"`
+	DireviewPada  *time.Time  `json:"direview_pada"`
+	Items         []APL02Item `gorm:"foreignKey:APL02ID" json:"items"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+func (APL02) TableName() string {
+	return "apl02"
+}
+
+// APL02Item is the self-assessment of a single elemen of a unit kompetensi.
+type APL02Item struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	APL02ID   uint      `gorm:"column:apl02_id;not null;uniqueIndex:idx_apl02_item_elemen" json:"apl02_id"`
+	ElemenID  uint      `gorm:"not null;uniqueIndex:idx_apl02_item_elemen" json:"elemen_id"`
+	Elemen    Elemen    `json:"elemen"`
+	Jawaban   string    `gorm:"size:2" json:"jawaban"`
+	Bukti     []Dokumen `gorm:"many2many:apl02_item_bukti;joinForeignKey:APL02ItemID;joinReferences:DokumenID" json:"bukti"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (APL02Item) TableName() string {
+	return "apl02_item"
+}
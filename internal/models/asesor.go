@@ -6,25 +6,59 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	StatusLisensiAktif     = "aktif"
+	StatusLisensiDibekukan = "dibekukan"
+	StatusLisensiDicabut   = "dicabut"
+)
+
+// Completeness rules an asesor record can be checked against
+const (
+	AturanKelengkapanProfil         = "profil"
+	AturanKelengkapanKompetensi     = "kompetensi"
+	AturanKelengkapanLisensi        = "lisensi"
+	AturanKelengkapanDokumenLisensi = "dokumen_lisensi"
+)
+
 type Asesor struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	NamaLengkap  string         `gorm:"size:150;not null" json:"nama_lengkap"`
-	NoRegistrasi string         `gorm:"size:50;uniqueIndex;not null" json:"no_registrasi"`
-	Email        string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
-	NoTelepon    string         `gorm:"size:20" json:"no_telepon"`
-	Kompetensi   []Kompetensi   `gorm:"many2many:asesor_kompetensi;" json:"kompetensi"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                       uint           `gorm:"primaryKey" json:"id"`
+	NamaLengkap              string         `gorm:"size:150;not null" json:"nama_lengkap"`
+	NoRegistrasi             string         `gorm:"size:50;uniqueIndex;not null" json:"no_registrasi"`
+	Email                    string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
+	NoTelepon                string         `gorm:"size:20" json:"no_telepon"`
+	Instansi                 string         `gorm:"size:150" json:"instansi"`
+	TanggalTerbitLisensi     *time.Time     `json:"tanggal_terbit_lisensi"`
+	TanggalKadaluarsaLisensi *time.Time     `gorm:"index" json:"tanggal_kadaluarsa_lisensi"`
+	PenerbitLisensi          string         `gorm:"size:100" json:"penerbit_lisensi"`
+	StatusLisensi            string         `gorm:"size:20;not null;default:aktif" json:"status_lisensi"`
+	DokumenLisensiID         *uint          `json:"dokumen_lisensi_id"`
+	DokumenLisensi           *Dokumen       `json:"dokumen_lisensi,omitempty"`
+	IsComplete               bool           `gorm:"not null;default:false;index" json:"is_complete"`
+	KelengkapanManual        bool           `gorm:"not null;default:false" json:"kelengkapan_manual"`
+	UserID                   *uint          `gorm:"uniqueIndex" json:"user_id"`
+	User                     *User          `json:"-"`
+	Kompetensi               []Kompetensi   `gorm:"many2many:asesor_kompetensi;" json:"kompetensi"`
+	CreatedAt                time.Time      `json:"created_at"`
+	UpdatedAt                time.Time      `json:"updated_at"`
+	DeletedAt                gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// LisensiBerlaku reports whether the asesor license is active at the given time.
+// The license stays valid until the end of its expiry date.
+func (a *Asesor) LisensiBerlaku(at time.Time) bool {
+	if a.StatusLisensi != StatusLisensiAktif || a.TanggalKadaluarsaLisensi == nil {
+		return false
+	}
+	return at.Before(a.TanggalKadaluarsaLisensi.AddDate(0, 0, 1))
 }
 
 type Kompetensi struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Nama        string         `gorm:"size:150;not null" json:"nama"`
-	Kode        string         `gorm:"size:50;uniqueIndex;not null" json:"kode"`
-	Deskripsi   string         `gorm:"type:text" json:"deskripsi"`
-	Asesor      []Asesor       `gorm:"many2many:asesor_kompetensi;" json:"-"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-}
\ No newline at end of file
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Nama      string         `gorm:"size:150;not null" json:"nama"`
+	Kode      string         `gorm:"size:50;uniqueIndex;not null" json:"kode"`
+	Deskripsi string         `gorm:"type:text" json:"deskripsi"`
+	Asesor    []Asesor       `gorm:"many2many:asesor_kompetensi;" json:"-"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type AsesorInvitation struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	AsesorID   uint       `gorm:"not null;index" json:"asesor_id"`
+	Asesor     Asesor     `json:"-"`
+	Email      string     `gorm:"size:100;not null" json:"email"`
+	Token      string     `gorm:"size:64;uniqueIndex;not null" json:"token"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
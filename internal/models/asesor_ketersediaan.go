@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	JenisKetersediaanTersedia      = "tersedia"
+	JenisKetersediaanTidakTersedia = "tidak_tersedia"
+)
+
+// AsesorKetersediaan is a period in which an asesor declared themselves
+// available, or a blackout period in which they cannot be scheduled.
+// Blackouts take precedence over overlapping availability.
+type AsesorKetersediaan struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AsesorID   uint      `gorm:"not null;index:idx_ketersediaan_asesor_periode" json:"asesor_id"`
+	Jenis      string    `gorm:"size:20;not null" json:"jenis"`
+	Mulai      time.Time `gorm:"not null;index:idx_ketersediaan_asesor_periode" json:"mulai"`
+	Selesai    time.Time `gorm:"not null" json:"selesai"`
+	Keterangan string    `gorm:"size:255" json:"keterangan"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (AsesorKetersediaan) TableName() string {
+	return "asesor_ketersediaan"
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// AsesorKompetensi is the join table behind Asesor.Kompetensi, carrying the
+// certification of an asesor for a single kompetensi.
+type AsesorKompetensi struct {
+	AsesorID        uint       `gorm:"primaryKey" json:"asesor_id"`
+	KompetensiID    uint       `gorm:"primaryKey" json:"kompetensi_id"`
+	Asesor          Asesor     `json:"-"`
+	Kompetensi      Kompetensi `json:"kompetensi"`
+	NomorSertifikat string     `gorm:"size:100" json:"nomor_sertifikat"`
+	BerlakuMulai    *time.Time `json:"berlaku_mulai"`
+	BerlakuSampai   *time.Time `gorm:"index" json:"berlaku_sampai"`
+	DokumenBuktiID  *uint      `json:"dokumen_bukti_id"`
+	DokumenBukti    *Dokumen   `json:"dokumen_bukti,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (AsesorKompetensi) TableName() string {
+	return "asesor_kompetensi"
+}
+
+// Berlaku reports whether the certification covers the given time. Both ends
+// of the validity period are inclusive by calendar day.
+func (ak *AsesorKompetensi) Berlaku(at time.Time) bool {
+	if ak.BerlakuMulai == nil || ak.BerlakuSampai == nil {
+		return false
+	}
+	return !at.Before(*ak.BerlakuMulai) && at.Before(ak.BerlakuSampai.AddDate(0, 0, 1))
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	JenisMediaFoto        = "foto"
+	JenisMediaTandaTangan = "tanda_tangan"
+)
+
+// AsesorMedia holds the normalized photo or signature of an asesor together
+// with its thumbnail, ready to be embedded in generated documents.
+type AsesorMedia struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AsesorID    uint      `gorm:"not null;uniqueIndex:idx_asesor_media_jenis" json:"asesor_id"`
+	Jenis       string    `gorm:"size:20;not null;uniqueIndex:idx_asesor_media_jenis" json:"jenis"`
+	DokumenID   uint      `gorm:"not null" json:"dokumen_id"`
+	Dokumen     Dokumen   `json:"-"`
+	ThumbnailID uint      `gorm:"not null" json:"thumbnail_id"`
+	Thumbnail   Dokumen   `json:"-"`
+	Lebar       int       `json:"lebar"`
+	Tinggi      int       `json:"tinggi"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
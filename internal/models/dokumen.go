@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	KategoriDokumenKTP         = "ktp"
+	KategoriDokumenIjazah      = "ijazah"
+	KategoriDokumenPortfolio   = "portfolio"
+	KategoriDokumenFormulir    = "formulir"
+	KategoriDokumenSertifikat  = "sertifikat"
+	KategoriDokumenLainnya     = "lainnya"
+	KategoriDokumenFoto        = "foto"
+	KategoriDokumenTandaTangan = "tanda_tangan"
+)
+
+type Dokumen struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	StorageKey  string         `gorm:"size:255;uniqueIndex;not null" json:"-"`
+	NamaFile    string         `gorm:"size:255;not null" json:"nama_file"`
+	ContentType string         `gorm:"size:100;not null" json:"content_type"`
+	Ukuran      int64          `gorm:"not null" json:"ukuran"`
+	Checksum    string         `gorm:"size:64;not null;index" json:"checksum"`
+	Kategori    string         `gorm:"size:30;not null;index" json:"kategori"`
+	UploadedBy  uint           `gorm:"index" json:"uploaded_by"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	StatusHasilDraft          = "draft"
+	StatusHasilDitandatangani = "ditandatangani"
+
+	KeputusanKompeten      = "K"
+	KeputusanBelumKompeten = "BK"
+
+	MetodeObservasi  = "observasi"
+	MetodeTertulis   = "tertulis"
+	MetodeLisan      = "lisan"
+	MetodePortofolio = "portofolio"
+)
+
+// HasilAsesmen records the outcome of an uji kompetensi for one asesi on one
+// jadwal. It can no longer be changed once the asesor has signed it off.
+type HasilAsesmen struct {
+	ID                 uint               `gorm:"primaryKey" json:"id"`
+	JadwalID           uint               `gorm:"not null;uniqueIndex:idx_hasil_jadwal_apl01" json:"jadwal_id"`
+	Jadwal             JadwalAsesmen      `json:"-"`
+	APL01ID            uint               `gorm:"column:apl01_id;not null;uniqueIndex:idx_hasil_jadwal_apl01" json:"apl01_id"`
+	AsesiID            uint               `gorm:"not null;index" json:"asesi_id"`
+	KompetensiID       uint               `gorm:"not null;index" json:"kompetensi_id"`
+	Kompetensi         Kompetensi         `json:"kompetensi"`
+	AsesorID           uint               `gorm:"not null;index" json:"asesor_id"`
+	Asesor             Asesor             `json:"asesor"`
+	MetodeAsesmen      []string           `gorm:"type:text;serializer:json" json:"metode_asesmen"`
+	Rekomendasi        string             `gorm:"size:2" json:"rekomendasi"`
+	Catatan            string             `gorm:"type:text" json:"catatan"`
+	Status             string             `gorm:"size:20;not null;default:draft;index" json:"status"`
+	DitandatanganiPada *time.Time         `json:"ditandatangani_pada"`
+	Units              []HasilAsesmenUnit `gorm:"foreignKey:HasilAsesmenID" json:"units"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+func (HasilAsesmen) TableName() string {
+	return "hasil_asesmen"
+}
+
+// HasilAsesmenUnit is the asesor's verdict for a single unit kompetensi.
+type HasilAsesmenUnit struct {
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	HasilAsesmenID   uint           `gorm:"not null;uniqueIndex:idx_hasil_unit" json:"hasil_asesmen_id"`
+	UnitKompetensiID uint           `gorm:"not null;uniqueIndex:idx_hasil_unit" json:"unit_kompetensi_id"`
+	UnitKompetensi   UnitKompetensi `json:"unit_kompetensi"`
+	Keputusan        string         `gorm:"size:2;not null" json:"keputusan"`
+	Catatan          string         `gorm:"type:text" json:"catatan"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+func (HasilAsesmenUnit) TableName() string {
+	return "hasil_asesmen_unit"
+}
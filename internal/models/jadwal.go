@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type JadwalAsesmen struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	KompetensiID   uint           `gorm:"not null;index" json:"kompetensi_id"`
+	Kompetensi     Kompetensi     `json:"kompetensi"`
+	TanggalMulai   time.Time      `gorm:"not null" json:"tanggal_mulai"`
+	TanggalSelesai time.Time      `gorm:"not null" json:"tanggal_selesai"`
+	TUKID          *uint          `gorm:"column:tuk_id;index" json:"tuk_id"`
+	TUK            *TUK           `json:"tuk,omitempty"`
+	Lokasi         string         `gorm:"size:255" json:"lokasi"`
+	Asesor         []Asesor       `gorm:"many2many:jadwal_asesor;" json:"asesor"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (JadwalAsesmen) TableName() string {
+	return "jadwal_asesmen"
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	JenisKonflikPekerjaan = "pekerjaan"
+	JenisKonflikKeluarga  = "keluarga"
+	JenisKonflikLainnya   = "lainnya"
+
+	SumberKonflikDeklarasi = "deklarasi"
+	SumberKonflikDeteksi   = "deteksi"
+)
+
+// KonflikKepentingan records that an asesor must not assess an asesi, either
+// declared by a person or detected from shared organization data.
+type KonflikKepentingan struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AsesorID    uint      `gorm:"not null;uniqueIndex:idx_konflik_pasangan" json:"asesor_id"`
+	Asesor      Asesor    `json:"-"`
+	AsesiID     uint      `gorm:"not null;uniqueIndex:idx_konflik_pasangan;index" json:"asesi_id"`
+	Jenis       string    `gorm:"size:20;not null;uniqueIndex:idx_konflik_pasangan" json:"jenis"`
+	Sumber      string    `gorm:"size:20;not null" json:"sumber"`
+	Keterangan  string    `gorm:"size:255" json:"keterangan"`
+	DicatatOleh *uint     `json:"dicatat_oleh"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (KonflikKepentingan) TableName() string {
+	return "konflik_kepentingan"
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// StatistikAsesor is one row of the per-asesor workload report. Periode is
+// empty when the report is not split by period.
+type StatistikAsesor struct {
+	AsesorID                uint    `json:"asesor_id"`
+	NamaLengkap             string  `json:"nama_lengkap"`
+	NoRegistrasi            string  `json:"no_registrasi"`
+	Periode                 string  `json:"periode"`
+	JumlahAsesmen           int64   `json:"jumlah_asesmen"`
+	JumlahKompeten          int64   `json:"jumlah_kompeten"`
+	JumlahBelumKompeten     int64   `json:"jumlah_belum_kompeten"`
+	TingkatKelulusan        float64 `gorm:"-" json:"tingkat_kelulusan"`
+	RataRataPenyelesaianJam float64 `json:"rata_rata_penyelesaian_jam"`
+}
+
+// StatistikKompetensi is one row of the per-kompetensi pass rate report.
+type StatistikKompetensi struct {
+	KompetensiID            uint    `json:"kompetensi_id"`
+	Kode                    string  `json:"kode"`
+	Nama                    string  `json:"nama"`
+	JumlahAsesmen           int64   `json:"jumlah_asesmen"`
+	JumlahKompeten          int64   `json:"jumlah_kompeten"`
+	JumlahBelumKompeten     int64   `json:"jumlah_belum_kompeten"`
+	TingkatKelulusan        float64 `gorm:"-" json:"tingkat_kelulusan"`
+	RataRataPenyelesaianJam float64 `json:"rata_rata_penyelesaian_jam"`
+}
+
+// AsesorIdle is an asesor with no schedule and no assessment in a period.
+type AsesorIdle struct {
+	AsesorID        uint       `json:"asesor_id"`
+	NamaLengkap     string     `json:"nama_lengkap"`
+	NoRegistrasi    string     `json:"no_registrasi"`
+	StatusLisensi   string     `json:"status_lisensi"`
+	TerakhirAsesmen *time.Time `json:"terakhir_asesmen"`
+}
+
+// BarisBNSP is one signed assessment result in the regulator reporting
+// format. Certificate columns are empty for asesi found belum kompeten.
+type BarisBNSP struct {
+	HasilAsesmenID     uint       `json:"hasil_asesmen_id"`
+	NamaAsesi          string     `json:"nama_asesi"`
+	NIK                string     `json:"nik"`
+	TempatLahir        string     `json:"tempat_lahir"`
+	TanggalLahir       *time.Time `json:"tanggal_lahir"`
+	JenisKelamin       string     `json:"jenis_kelamin"`
+	Kebangsaan         string     `json:"kebangsaan"`
+	AlamatRumah        string     `json:"alamat_rumah"`
+	KodePos            string     `json:"kode_pos"`
+	NoTelepon          string     `json:"no_telepon"`
+	Email              string     `json:"email"`
+	PendidikanTerakhir string     `json:"pendidikan_terakhir"`
+	NamaInstitusi      string     `json:"nama_institusi"`
+	Jabatan            string     `json:"jabatan"`
+	KodeSkema          string     `json:"kode_skema"`
+	NamaSkema          string     `json:"nama_skema"`
+	KodeTUK            string     `gorm:"column:kode_tuk" json:"kode_tuk"`
+	NamaTUK            string     `gorm:"column:nama_tuk" json:"nama_tuk"`
+	NoRegistrasiAsesor string     `json:"no_registrasi_asesor"`
+	NamaAsesor         string     `json:"nama_asesor"`
+	TanggalUji         time.Time  `json:"tanggal_uji"`
+	Rekomendasi        string     `json:"rekomendasi"`
+	NomorSertifikat    string     `json:"nomor_sertifikat"`
+	TanggalTerbit      *time.Time `json:"tanggal_terbit"`
+}
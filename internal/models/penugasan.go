@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	StatusUsulanDraft    = "draft"
+	StatusUsulanDiterima = "diterima"
+)
+
+// JadwalPeserta is an asesi taking part in a jadwal, together with the asesor
+// who assesses them.
+type JadwalPeserta struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	JadwalID  uint      `gorm:"not null;uniqueIndex:idx_jadwal_peserta" json:"jadwal_id"`
+	APL01ID   uint      `gorm:"column:apl01_id;not null;uniqueIndex:idx_jadwal_peserta" json:"apl01_id"`
+	AsesiID   uint      `gorm:"not null;index" json:"asesi_id"`
+	AsesorID  *uint     `gorm:"index" json:"asesor_id"`
+	Asesor    *Asesor   `json:"asesor,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (JadwalPeserta) TableName() string {
+	return "jadwal_peserta"
+}
+
+// UsulanPenugasan is a proposed assignment of asesors to the asesi of a
+// jadwal. Staff may adjust its items before accepting it.
+type UsulanPenugasan struct {
+	ID                 uint                  `gorm:"primaryKey" json:"id"`
+	JadwalID           uint                  `gorm:"not null;index" json:"jadwal_id"`
+	Status             string                `gorm:"size:20;not null;default:draft" json:"status"`
+	MaksAsesiPerAsesor int                   `gorm:"not null" json:"maks_asesi_per_asesor"`
+	DibuatOleh         uint                  `gorm:"not null" json:"dibuat_oleh"`
+	DiterimaOleh       *uint                 `json:"diterima_oleh"`
+	DiterimaPada       *time.Time            `json:"diterima_pada"`
+	Items              []UsulanPenugasanItem `gorm:"foreignKey:UsulanPenugasanID" json:"items"`
+	CreatedAt          time.Time             `json:"created_at"`
+	UpdatedAt          time.Time             `json:"updated_at"`
+}
+
+func (UsulanPenugasan) TableName() string {
+	return "usulan_penugasan"
+}
+
+// UsulanPenugasanItem proposes an asesor for one asesi. AsesorID is nil when
+// no suitable asesor was found, with the reason in Catatan.
+type UsulanPenugasanItem struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	UsulanPenugasanID uint      `gorm:"not null;uniqueIndex:idx_usulan_item" json:"usulan_penugasan_id"`
+	APL01ID           uint      `gorm:"column:apl01_id;not null;uniqueIndex:idx_usulan_item" json:"apl01_id"`
+	AsesiID           uint      `gorm:"not null" json:"asesi_id"`
+	NamaAsesi         string    `gorm:"size:150" json:"nama_asesi"`
+	AsesorID          *uint     `json:"asesor_id"`
+	Asesor            *Asesor   `json:"asesor,omitempty"`
+	Catatan           string    `gorm:"size:255" json:"catatan"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func (UsulanPenugasanItem) TableName() string {
+	return "usulan_penugasan_item"
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	StatusSertifikatAktif      = "aktif"
+	StatusSertifikatDibekukan  = "dibekukan"
+	StatusSertifikatDicabut    = "dicabut"
+	StatusSertifikatDiperbarui = "diperbarui"
+
+	// StatusSertifikatKadaluarsa is never stored; it is reported for active
+	// certificates past their validity.
+	StatusSertifikatKadaluarsa = "kadaluarsa"
+)
+
+// sertifikatTransitions lists the statuses each certificate status may be
+// moved to by staff. Renewal moves an active certificate to diperbarui.
+var sertifikatTransitions = map[string][]string{
+	StatusSertifikatAktif:     {StatusSertifikatDibekukan, StatusSertifikatDicabut},
+	StatusSertifikatDibekukan: {StatusSertifikatAktif, StatusSertifikatDicabut},
+}
+
+// Sertifikat is the certificate of competence issued to an asesi who was
+// declared kompeten in a signed-off HasilAsesmen. A renewal (resertifikasi)
+// is a new certificate pointing at the one it replaces.
+type Sertifikat struct {
+	ID              uint                `gorm:"primaryKey" json:"id"`
+	NomorSertifikat string              `gorm:"size:100;uniqueIndex;not null" json:"nomor_sertifikat"`
+	HasilAsesmenID  *uint               `gorm:"uniqueIndex" json:"hasil_asesmen_id"`
+	SebelumnyaID    *uint               `gorm:"uniqueIndex" json:"sebelumnya_id"`
+	Sebelumnya      *Sertifikat         `json:"sebelumnya,omitempty"`
+	AsesiID         uint                `gorm:"not null;index" json:"asesi_id"`
+	KompetensiID    uint                `gorm:"not null;index" json:"kompetensi_id"`
+	Kompetensi      Kompetensi          `json:"kompetensi"`
+	NamaPemegang    string              `gorm:"size:150;not null" json:"nama_pemegang"`
+	TanggalTerbit   time.Time           `gorm:"not null" json:"tanggal_terbit"`
+	BerlakuSampai   time.Time           `gorm:"not null;index" json:"berlaku_sampai"`
+	Status          string              `gorm:"size:20;not null;default:aktif;index" json:"status"`
+	Alasan          string              `gorm:"type:text" json:"alasan"`
+	DiterbitkanOleh uint                `gorm:"not null" json:"diterbitkan_oleh"`
+	Riwayat         []SertifikatRiwayat `gorm:"foreignKey:SertifikatID" json:"riwayat"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+func (Sertifikat) TableName() string {
+	return "sertifikat"
+}
+
+// Berlaku reports whether the certificate is active at the given time. It stays
+// valid until the end of its expiry date.
+func (s *Sertifikat) Berlaku(at time.Time) bool {
+	return s.Status == StatusSertifikatAktif && at.Before(s.BerlakuSampai.AddDate(0, 0, 1))
+}
+
+func (s *Sertifikat) CanTransitionTo(status string) bool {
+	for _, next := range sertifikatTransitions[s.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusAt is the status reported to the public, which marks active
+// certificates past their validity as expired.
+func (s *Sertifikat) StatusAt(at time.Time) string {
+	if s.Status == StatusSertifikatAktif && !s.Berlaku(at) {
+		return StatusSertifikatKadaluarsa
+	}
+	return s.Status
+}
+
+// SertifikatRiwayat records every status change of a certificate with the
+// reason and the staff member who made it.
+type SertifikatRiwayat struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	SertifikatID uint      `gorm:"not null;index" json:"sertifikat_id"`
+	DariStatus   string    `gorm:"size:20" json:"dari_status"`
+	KeStatus     string    `gorm:"size:20;not null" json:"ke_status"`
+	Alasan       string    `gorm:"type:text" json:"alasan"`
+	OlehUserID   uint      `gorm:"not null" json:"oleh_user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (SertifikatRiwayat) TableName() string {
+	return "sertifikat_riwayat"
+}
+
+// SertifikatCounter holds the last certificate sequence allocated per scope.
+// Rows are locked while a number is allocated, so sequences have no gaps.
+type SertifikatCounter struct {
+	Scope     string    `gorm:"primaryKey;size:191" json:"scope"`
+	Nilai     uint64    `gorm:"not null;default:0" json:"nilai"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (SertifikatCounter) TableName() string {
+	return "sertifikat_counter"
+}
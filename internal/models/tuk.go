@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	JenisTUKSewaktu     = "sewaktu"
+	JenisTUKTempatKerja = "tempat_kerja"
+	JenisTUKMandiri     = "mandiri"
+
+	StatusVerifikasiTUKMenunggu      = "menunggu"
+	StatusVerifikasiTUKTerverifikasi = "terverifikasi"
+	StatusVerifikasiTUKDitolak       = "ditolak"
+)
+
+// TUK (Tempat Uji Kompetensi) is a test site where assessments take place.
+// Only the kompetensi listed for a TUK may be assessed there.
+type TUK struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	Kode              string         `gorm:"size:50;uniqueIndex;not null" json:"kode"`
+	Nama              string         `gorm:"size:150;not null" json:"nama"`
+	Jenis             string         `gorm:"size:20;not null" json:"jenis"`
+	Alamat            string         `gorm:"type:text;not null" json:"alamat"`
+	Kapasitas         int            `gorm:"not null;default:0" json:"kapasitas"`
+	Fasilitas         string         `gorm:"type:text" json:"fasilitas"`
+	StatusVerifikasi  string         `gorm:"size:20;not null;default:menunggu" json:"status_verifikasi"`
+	TanggalVerifikasi *time.Time     `json:"tanggal_verifikasi"`
+	BerlakuSampai     *time.Time     `gorm:"index" json:"berlaku_sampai"`
+	Kompetensi        []Kompetensi   `gorm:"many2many:tuk_kompetensi;" json:"kompetensi"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (TUK) TableName() string {
+	return "tuk"
+}
+
+// Terverifikasi reports whether the TUK verification is valid at the given
+// time. It stays valid until the end of its expiry date.
+func (t *TUK) Terverifikasi(at time.Time) bool {
+	if t.StatusVerifikasi != StatusVerifikasiTUKTerverifikasi || t.BerlakuSampai == nil {
+		return false
+	}
+	return at.Before(t.BerlakuSampai.AddDate(0, 0, 1))
+}
+
+func (t *TUK) MenerimaKompetensi(kompetensiID uint) bool {
+	for _, kompetensi := range t.Kompetensi {
+		if kompetensi.ID == kompetensiID {
+			return true
+		}
+	}
+	return false
+}
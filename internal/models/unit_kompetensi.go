@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// UnitKompetensi, Elemen and KriteriaUnjukKerja form the competency structure
+// of a skema, from which assessment instruments such as APL-02 are generated.
+type UnitKompetensi struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	KompetensiID uint      `gorm:"not null;index" json:"kompetensi_id"`
+	KodeUnit     string    `gorm:"size:50;not null" json:"kode_unit"`
+	JudulUnit    string    `gorm:"size:255;not null" json:"judul_unit"`
+	Urutan       int       `gorm:"not null;default:0" json:"urutan"`
+	Elemen       []Elemen  `gorm:"foreignKey:UnitKompetensiID" json:"elemen"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (UnitKompetensi) TableName() string {
+	return "unit_kompetensi"
+}
+
+type Elemen struct {
+	ID                 uint                 `gorm:"primaryKey" json:"id"`
+	UnitKompetensiID   uint                 `gorm:"not null;index" json:"unit_kompetensi_id"`
+	Nama               string               `gorm:"size:255;not null" json:"nama"`
+	Urutan             int                  `gorm:"not null;default:0" json:"urutan"`
+	KriteriaUnjukKerja []KriteriaUnjukKerja `gorm:"foreignKey:ElemenID" json:"kriteria_unjuk_kerja"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+}
+
+func (Elemen) TableName() string {
+	return "elemen"
+}
+
+type KriteriaUnjukKerja struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ElemenID  uint      `gorm:"not null;index" json:"elemen_id"`
+	Deskripsi string    `gorm:"type:text;not null" json:"deskripsi"`
+	Urutan    int       `gorm:"not null;default:0" json:"urutan"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (KriteriaUnjukKerja) TableName() string {
+	return "kriteria_unjuk_kerja"
+}
@@ -7,15 +7,23 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	RoleAdmin  = "admin"
+	RoleAsesor = "asesor"
+	RoleAsesi  = "asesi"
+)
+
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Username  string         `gorm:"size:100;not null" json:"username"`
-	FullName  string         `gorm:"size:150;not null" json:"full_name"`
-	Email     string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
-	Password  string         `gorm:"size:100;not null" json:"-"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	Username      string         `gorm:"size:100;not null" json:"username"`
+	FullName      string         `gorm:"size:150;not null" json:"full_name"`
+	Email         string         `gorm:"size:100;uniqueIndex;not null" json:"email"`
+	Password      string         `gorm:"size:100;not null" json:"-"`
+	Role          string         `gorm:"size:20;not null" json:"role"`
+	CalendarToken *string        `gorm:"size:64;uniqueIndex" json:"-"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) error {
@@ -30,4 +38,4 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 func (u *User) ComparePassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 	return err == nil
-}
\ No newline at end of file
+}
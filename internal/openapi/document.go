@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operation describes one route of the API. Path uses gin syntax so it can be
+// compared with the routes registered on the router.
+type Operation struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+
+	// Auth marks routes behind the bearer token middleware and Roles lists
+	// the roles allowed when the route is restricted further.
+	Auth  bool
+	Roles []string
+
+	Query []Param
+
+	// Request is the JSON body. Form and Files describe a multipart body
+	// instead, Form by its form tags and Files by field name.
+	Request any
+	Form    any
+	Files   []string
+
+	// Status is the success status, 200 when zero. Response is the data of
+	// the response envelope; ContentType replaces the envelope for routes
+	// that stream a file.
+	Status      int
+	Response    any
+	ContentType string
+}
+
+// Param is a query string parameter.
+type Param struct {
+	Name        string
+	Description string
+	Required    bool
+	Type        string
+}
+
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Build assembles the OpenAPI 3 document. envelope is the JSON wrapper every
+// handler responds with; its data field is replaced per operation.
+func Build(info Info, envelope any, operations []Operation) map[string]any {
+	s := &schemas{components: map[string]any{}}
+	envelopeRef := s.ref(reflect.TypeOf(envelope))
+
+	paths := map[string]any{}
+	for _, op := range operations {
+		key := pathTemplate(op.Path)
+		item, ok := paths[key].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[key] = item
+		}
+		item[strings.ToLower(op.Method)] = s.operation(op, envelopeRef)
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       info.Title,
+			"version":     info.Version,
+			"description": info.Description,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": s.components,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+	}
+}
+
+func (s *schemas) operation(op Operation, envelopeRef map[string]any) map[string]any {
+	operation := map[string]any{
+		"summary": op.Summary,
+	}
+	if op.Tag != "" {
+		operation["tags"] = []string{op.Tag}
+	}
+	if len(op.Roles) > 0 {
+		operation["description"] = "Allowed roles: " + strings.Join(op.Roles, ", ")
+	}
+	if op.Auth {
+		operation["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+	}
+
+	var parameters []any
+	for _, name := range pathParams(op.Path) {
+		parameters = append(parameters, map[string]any{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   paramSchema(name, ""),
+		})
+	}
+	for _, param := range op.Query {
+		parameter := map[string]any{
+			"name":     param.Name,
+			"in":       "query",
+			"required": param.Required,
+			"schema":   paramSchema(param.Name, param.Type),
+		}
+		if param.Description != "" {
+			parameter["description"] = param.Description
+		}
+		parameters = append(parameters, parameter)
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	switch {
+	case op.Request != nil:
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": s.ref(reflect.TypeOf(op.Request))},
+			},
+		}
+	case op.Form != nil || len(op.Files) > 0:
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"multipart/form-data": map[string]any{"schema": s.multipart(op)},
+			},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var success map[string]any
+	if op.ContentType != "" {
+		success = map[string]any{
+			op.ContentType: map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}},
+		}
+	} else {
+		schema := envelopeRef
+		if op.Response != nil {
+			schema = map[string]any{
+				"allOf": []any{
+					envelopeRef,
+					map[string]any{
+						"type":       "object",
+						"properties": map[string]any{"data": s.ref(reflect.TypeOf(op.Response))},
+					},
+				},
+			}
+		}
+		success = map[string]any{"application/json": map[string]any{"schema": schema}}
+	}
+
+	operation["responses"] = map[string]any{
+		strconv.Itoa(status): map[string]any{
+			"description": http.StatusText(status),
+			"content":     success,
+		},
+		"default": map[string]any{
+			"description": "Error",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": envelopeRef},
+			},
+		},
+	}
+
+	return operation
+}
+
+func (s *schemas) multipart(op Operation) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	if op.Form != nil {
+		s.fields(reflect.TypeOf(op.Form), "form", properties, &required)
+	}
+	for _, name := range op.Files {
+		properties[name] = map[string]any{"type": "string", "format": "binary"}
+		required = append(required, name)
+	}
+
+	sort.Strings(required)
+	return map[string]any{"type": "object", "properties": properties, "required": required}
+}
+
+// pathTemplate turns gin parameters such as :id into OpenAPI {id}.
+func pathTemplate(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(ginPath string) []string {
+	var names []string
+	for _, segment := range strings.Split(ginPath, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			names = append(names, segment[1:])
+		}
+	}
+	return names
+}
+
+// paramSchema types ID parameters as integers and anything else as the
+// given type, string by default.
+func paramSchema(name, typ string) map[string]any {
+	if typ == "" {
+		typ = "string"
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			typ = "integer"
+		}
+	}
+	return map[string]any{"type": typ}
+}
@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// Handler serves the document, encoded once.
+func Handler(document map[string]any) gin.HandlerFunc {
+	body, err := json.Marshal(document)
+	return func(ctx *gin.Context) {
+		if err != nil {
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
+
+// swaggerUIContentSecurityPolicy allows what the bundled Swagger UI needs:
+// its own scripts, inline styles and data: images, and calls to the API.
+const swaggerUIContentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+// SwaggerUI serves the embedded Swagger UI under prefix, pointed at the
+// document at specURL. Register it on prefix + "/*filepath".
+func SwaggerUI(prefix, specURL string) gin.HandlerFunc {
+	files := http.StripPrefix(prefix, http.FileServer(http.FS(swaggerFiles.FS)))
+	initializer := fmt.Sprintf(`window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: %q,
+    dom_id: "#swagger-ui",
+    validatorUrl: null,
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`, specURL)
+
+	return func(ctx *gin.Context) {
+		ctx.Header("Content-Security-Policy", swaggerUIContentSecurityPolicy)
+		if ctx.Param("filepath") == "/swagger-initializer.js" {
+			ctx.Data(http.StatusOK, "application/javascript; charset=utf-8", []byte(initializer))
+			return
+		}
+		files.ServeHTTP(ctx.Writer, ctx.Request)
+	}
+}
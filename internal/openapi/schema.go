@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemas collects the named struct types referenced by the document so each
+// is described once under components.
+type schemas struct {
+	components map[string]any
+}
+
+// ref returns the schema of t, registering named structs as components.
+func (s *schemas) ref(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(s.ref(t.Elem()))
+	case reflect.Interface:
+		return map[string]any{}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": s.ref(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.ref(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return s.object(t)
+		}
+
+		name := componentName(t)
+		if _, ok := s.components[name]; !ok {
+			// Reserve the name first so self-referencing types terminate
+			s.components[name] = nil
+			s.components[name] = s.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+// object describes the exported JSON fields of a struct, flattening
+// embedded structs the way encoding/json does.
+func (s *schemas) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	s.fields(t, "json", properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// fields adds the fields of t named by the given struct tag, json for bodies
+// and form for multipart forms.
+func (s *schemas) fields(t reflect.Type, tag string, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.fields(embedded, tag, properties, required)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			if tag != "json" {
+				continue
+			}
+			name = field.Name
+		}
+
+		schema := s.ref(field.Type)
+		if applyBinding(schema, field.Type, field.Tag.Get("binding")) {
+			*required = append(*required, name)
+		}
+		properties[name] = schema
+	}
+}
+
+// applyBinding copies the validator rules of a binding tag that have an
+// OpenAPI equivalent onto schema and reports whether the field is required.
+// Rules after dive apply to slice elements and are not described.
+func applyBinding(schema map[string]any, t reflect.Type, binding string) bool {
+	if binding == "" {
+		return false
+	}
+
+	target := schema
+	if _, ok := schema["$ref"]; ok {
+		target = map[string]any{}
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		case "email":
+			target["format"] = "email"
+		case "datetime":
+			if value == "2006-01-02" {
+				target["format"] = "date"
+			}
+		case "oneof":
+			var enum []any
+			for _, option := range strings.Fields(value) {
+				enum = append(enum, option)
+			}
+			target["enum"] = enum
+		case "min", "max", "len":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			for _, bound := range boundKeys(t, key) {
+				target[bound] = n
+			}
+		}
+	}
+	return required
+}
+
+// boundKeys maps a validator length rule to the schema keywords for the kind
+// of value it constrains.
+func boundKeys(t reflect.Type, rule string) []string {
+	var min, max string
+	switch t.Kind() {
+	case reflect.String:
+		min, max = "minLength", "maxLength"
+	case reflect.Slice, reflect.Array:
+		min, max = "minItems", "maxItems"
+	case reflect.Map, reflect.Struct, reflect.Bool, reflect.Interface:
+		return nil
+	default:
+		min, max = "minimum", "maximum"
+	}
+
+	switch rule {
+	case "min":
+		return []string{min}
+	case "max":
+		return []string{max}
+	default:
+		return []string{min, max}
+	}
+}
+
+func nullable(schema map[string]any) map[string]any {
+	if _, ok := schema["$ref"]; ok {
+		return map[string]any{"allOf": []any{schema}, "nullable": true}
+	}
+	schema["nullable"] = true
+	return schema
+}
+
+// componentName qualifies a type name by its package, e.g. models.Asesor.
+func componentName(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
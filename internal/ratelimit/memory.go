@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	bucket
+	limit Limit
+}
+
+// MemoryStore keeps buckets in process. Each API instance enforces its own
+// limits, so behind a load balancer clients get the limit once per instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*memoryEntry),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	entry, ok := s.buckets[key]
+	if !ok {
+		entry = &memoryEntry{}
+		s.buckets[key] = entry
+	}
+	entry.limit = limit
+
+	return entry.take(limit, now), nil
+}
+
+// sweep drops buckets that have refilled, keeping memory bounded by the
+// number of clients active within the refill time.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, entry := range s.buckets {
+		if entry.full(entry.limit, now) {
+			delete(s.buckets, key)
+		}
+	}
+}
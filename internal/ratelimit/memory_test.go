@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = clock.Now
+	s.lastSweep = clock.now
+	return s, clock
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	// One token per second, three at most
+	limit := PerMinute(60, 3)
+
+	steps := []struct {
+		advance time.Duration
+		want    Result
+	}{
+		{0, Result{Allowed: true, Remaining: 2, Reset: time.Second}},
+		{0, Result{Allowed: true, Remaining: 1, Reset: 2 * time.Second}},
+		{0, Result{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+		{0, Result{Allowed: false, Remaining: 0, RetryAfter: time.Second, Reset: 3 * time.Second}},
+		{500 * time.Millisecond, Result{Allowed: false, Remaining: 0, RetryAfter: 500 * time.Millisecond, Reset: 2500 * time.Millisecond}},
+		{500 * time.Millisecond, Result{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+		{1500 * time.Millisecond, Result{Allowed: true, Remaining: 0, Reset: 2500 * time.Millisecond}},
+		// Refilling stops at the burst
+		{time.Hour, Result{Allowed: true, Remaining: 2, Reset: time.Second}},
+	}
+
+	s, clock := newTestStore()
+	for i, step := range steps {
+		clock.Advance(step.advance)
+		got, err := s.Take(context.Background(), "ip:10.0.0.1", limit)
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if got != step.want {
+			t.Errorf("step %d: got %+v, want %+v", i, got, step.want)
+		}
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	limit := PerMinute(1, 1)
+	s, _ := newTestStore()
+
+	for _, key := range []string{"ip:10.0.0.1", "ip:10.0.0.2", "user:1"} {
+		got, _ := s.Take(context.Background(), key, limit)
+		if !got.Allowed {
+			t.Errorf("first request of %s was denied", key)
+		}
+	}
+
+	got, _ := s.Take(context.Background(), "ip:10.0.0.1", limit)
+	if got.Allowed || got.RetryAfter != time.Minute {
+		t.Errorf("second request got %+v, want denied for a minute", got)
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	fast := PerMinute(60, 3)
+	slow := PerMinute(1, 5)
+
+	s, clock := newTestStore()
+	ctx := context.Background()
+	s.Take(ctx, "refilled", fast)
+	s.Take(ctx, "draining", slow)
+	s.Take(ctx, "draining", slow)
+
+	// Not swept before the interval, even though the fast bucket is full
+	clock.Advance(sweepInterval - time.Second)
+	s.Take(ctx, "other", fast)
+	if _, ok := s.buckets["refilled"]; !ok {
+		t.Fatalf("bucket swept before the sweep interval")
+	}
+
+	clock.Advance(2 * time.Second)
+	s.Take(ctx, "other", fast)
+
+	for key, want := range map[string]bool{"refilled": false, "draining": true, "other": true} {
+		if _, ok := s.buckets[key]; ok != want {
+			t.Errorf("bucket %s kept = %v, want %v", key, ok, want)
+		}
+	}
+}
+
+func TestLimitDisabled(t *testing.T) {
+	tests := []struct {
+		limit Limit
+		want  bool
+	}{
+		{PerMinute(30, 10), false},
+		{PerMinute(0, 10), true},
+		{PerMinute(30, 0), true},
+		{Limit{}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.limit.Disabled(); got != tt.want {
+			t.Errorf("%+v.Disabled() = %v, want %v", tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type APL01Repository interface {
+	Create(ctx context.Context, apl01 *models.APL01) error
+	Update(ctx context.Context, apl01 *models.APL01) error
+	FindByID(ctx context.Context, id uint) (*models.APL01, error)
+	FindByAsesiID(ctx context.Context, asesiID uint) ([]models.APL01, error)
+	FindAll(ctx context.Context, status string) ([]models.APL01, error)
+	AddDokumen(ctx context.Context, apl01Dokumen *models.APL01Dokumen) error
+	RemoveDokumen(ctx context.Context, apl01ID, dokumenID uint) error
+	ChangeStatus(ctx context.Context, apl01 *models.APL01, riwayat *models.APL01Riwayat) error
+}
+
+type apl01Repository struct {
+	db *gorm.DB
+}
+
+func NewAPL01Repository(db *gorm.DB) APL01Repository {
+	return &apl01Repository{db: db}
+}
+
+func (r *apl01Repository) Create(ctx context.Context, apl01 *models.APL01) error {
+	return r.db.WithContext(ctx).Omit("Kompetensi", "Asesi").Create(apl01).Error
+}
+
+func (r *apl01Repository) Update(ctx context.Context, apl01 *models.APL01) error {
+	return r.db.WithContext(ctx).Omit("Kompetensi", "Asesi", "Dokumen", "Riwayat").Save(apl01).Error
+}
+
+func (r *apl01Repository) FindByID(ctx context.Context, id uint) (*models.APL01, error) {
+	var apl01 models.APL01
+	err := r.db.WithContext(ctx).
+		Preload("Kompetensi").
+		Preload("Dokumen.Dokumen").
+		Preload("Riwayat", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&apl01, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &apl01, nil
+}
+
+func (r *apl01Repository) FindByAsesiID(ctx context.Context, asesiID uint) ([]models.APL01, error) {
+	var apl01 []models.APL01
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Where("asesi_id = ?", asesiID).Order("created_at DESC").Find(&apl01).Error
+	if err != nil {
+		return nil, err
+	}
+	return apl01, nil
+}
+
+func (r *apl01Repository) FindAll(ctx context.Context, status string) ([]models.APL01, error) {
+	var apl01 []models.APL01
+	query := r.db.WithContext(ctx).Preload("Kompetensi").Order("created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&apl01).Error
+	if err != nil {
+		return nil, err
+	}
+	return apl01, nil
+}
+
+func (r *apl01Repository) AddDokumen(ctx context.Context, apl01Dokumen *models.APL01Dokumen) error {
+	return r.db.WithContext(ctx).Omit("Dokumen").Create(apl01Dokumen).Error
+}
+
+func (r *apl01Repository) RemoveDokumen(ctx context.Context, apl01ID, dokumenID uint) error {
+	result := r.db.WithContext(ctx).Where("apl01_id = ? AND dokumen_id = ?", apl01ID, dokumenID).Delete(&models.APL01Dokumen{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ChangeStatus persists the new status and its history entry atomically. It
+// returns gorm.ErrRecordNotFound when the status is no longer the one the
+// transition started from, so concurrent transitions cannot both apply.
+func (r *apl01Repository) ChangeStatus(ctx context.Context, apl01 *models.APL01, riwayat *models.APL01Riwayat) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.APL01{}).
+			Where("id = ? AND status = ?", apl01.ID, riwayat.DariStatus).
+			Updates(map[string]interface{}{
+				"status":        apl01.Status,
+				"catatan":       apl01.Catatan,
+				"diajukan_pada": apl01.DiajukanPada,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(riwayat).Error
+	})
+}
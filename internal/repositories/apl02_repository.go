@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type APL02Repository interface {
+	Create(ctx context.Context, apl02 *models.APL02) error
+	Update(ctx context.Context, apl02 *models.APL02) error
+	FindByID(ctx context.Context, id uint) (*models.APL02, error)
+	FindByAPL01ID(ctx context.Context, apl01ID uint) (*models.APL02, error)
+	FindAll(ctx context.Context, asesiID, asesorID uint) ([]models.APL02, error)
+	UpdateItem(ctx context.Context, item *models.APL02Item, bukti []models.Dokumen) error
+}
+
+type apl02Repository struct {
+	db *gorm.DB
+}
+
+func NewAPL02Repository(db *gorm.DB) APL02Repository {
+	return &apl02Repository{db: db}
+}
+
+func (r *apl02Repository) Create(ctx context.Context, apl02 *models.APL02) error {
+	return r.db.WithContext(ctx).Omit("Kompetensi", "Asesor", "Items.Elemen", "Items.Bukti").Create(apl02).Error
+}
+
+func (r *apl02Repository) Update(ctx context.Context, apl02 *models.APL02) error {
+	return r.db.WithContext(ctx).Omit("Kompetensi", "Asesor", "Items").Save(apl02).Error
+}
+
+func (r *apl02Repository) FindByID(ctx context.Context, id uint) (*models.APL02, error) {
+	var apl02 models.APL02
+	err := r.db.WithContext(ctx).
+		Preload("Kompetensi").
+		Preload("Asesor").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Elemen.KriteriaUnjukKerja").
+		Preload("Items.Bukti").
+		First(&apl02, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &apl02, nil
+}
+
+func (r *apl02Repository) FindByAPL01ID(ctx context.Context, apl01ID uint) (*models.APL02, error) {
+	var apl02 models.APL02
+	err := r.db.WithContext(ctx).Where("apl01_id = ?", apl01ID).First(&apl02).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.FindByID(ctx, apl02.ID)
+}
+
+// FindAll lists APL-02 forms, optionally narrowed to one asesi or one asesor.
+// A zero ID means no filter on that column.
+func (r *apl02Repository) FindAll(ctx context.Context, asesiID, asesorID uint) ([]models.APL02, error) {
+	var apl02 []models.APL02
+	query := r.db.WithContext(ctx).Preload("Kompetensi").Preload("Asesor").Order("created_at DESC")
+	if asesiID != 0 {
+		query = query.Where("asesi_id = ?", asesiID)
+	}
+	if asesorID != 0 {
+		query = query.Where("asesor_id = ?", asesorID)
+	}
+	err := query.Find(&apl02).Error
+	if err != nil {
+		return nil, err
+	}
+	return apl02, nil
+}
+
+func (r *apl02Repository) UpdateItem(ctx context.Context, item *models.APL02Item, bukti []models.Dokumen) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(item).Update("jawaban", item.Jawaban).Error; err != nil {
+			return err
+		}
+		return tx.Model(item).Omit("Bukti.*").Association("Bukti").Replace(bukti)
+	})
+}
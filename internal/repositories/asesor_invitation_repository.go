@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AsesorInvitationRepository interface {
+	Create(ctx context.Context, invitation *models.AsesorInvitation) error
+	FindByToken(ctx context.Context, token string) (*models.AsesorInvitation, error)
+	Accept(ctx context.Context, invitation *models.AsesorInvitation, user *models.User) error
+}
+
+type asesorInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewAsesorInvitationRepository(db *gorm.DB) AsesorInvitationRepository {
+	return &asesorInvitationRepository{db: db}
+}
+
+func (r *asesorInvitationRepository) Create(ctx context.Context, invitation *models.AsesorInvitation) error {
+	return r.db.WithContext(ctx).Create(invitation).Error
+}
+
+func (r *asesorInvitationRepository) FindByToken(ctx context.Context, token string) (*models.AsesorInvitation, error) {
+	var invitation models.AsesorInvitation
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// Accept creates the user account, links it to the invited asesor and marks
+// the invitation as used in a single transaction.
+func (r *asesorInvitationRepository) Accept(ctx context.Context, invitation *models.AsesorInvitation, user *models.User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Asesor{}).
+			Where("id = ? AND user_id IS NULL", invitation.AsesorID).
+			Update("user_id", user.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		now := time.Now()
+		invitation.AcceptedAt = &now
+		return tx.Model(invitation).Update("accepted_at", now).Error
+	})
+}
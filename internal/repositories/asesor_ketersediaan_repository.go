@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AsesorKetersediaanRepository interface {
+	Create(ctx context.Context, ketersediaan *models.AsesorKetersediaan) error
+	Delete(ctx context.Context, asesorID, id uint) error
+	FindOverlapping(ctx context.Context, asesorIDs []uint, from, to time.Time) ([]models.AsesorKetersediaan, error)
+}
+
+type asesorKetersediaanRepository struct {
+	db *gorm.DB
+}
+
+func NewAsesorKetersediaanRepository(db *gorm.DB) AsesorKetersediaanRepository {
+	return &asesorKetersediaanRepository{db: db}
+}
+
+func (r *asesorKetersediaanRepository) Create(ctx context.Context, ketersediaan *models.AsesorKetersediaan) error {
+	return r.db.WithContext(ctx).Create(ketersediaan).Error
+}
+
+func (r *asesorKetersediaanRepository) Delete(ctx context.Context, asesorID, id uint) error {
+	result := r.db.WithContext(ctx).Where("asesor_id = ? AND id = ?", asesorID, id).Delete(&models.AsesorKetersediaan{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindOverlapping returns the periods of the given asesors that overlap the
+// half-open range from..to.
+func (r *asesorKetersediaanRepository) FindOverlapping(ctx context.Context, asesorIDs []uint, from, to time.Time) ([]models.AsesorKetersediaan, error) {
+	var ketersediaan []models.AsesorKetersediaan
+	err := r.db.WithContext(ctx).
+		Where("asesor_id IN ? AND mulai < ? AND selesai > ?", asesorIDs, to, from).
+		Order("mulai ASC").
+		Find(&ketersediaan).Error
+	if err != nil {
+		return nil, err
+	}
+	return ketersediaan, nil
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AsesorKompetensiRepository interface {
+	Save(ctx context.Context, asesorKompetensi *models.AsesorKompetensi) error
+	Delete(ctx context.Context, asesorID, kompetensiID uint) error
+	FindByAsesorID(ctx context.Context, asesorID uint) ([]models.AsesorKompetensi, error)
+	FindByAsesorAndKompetensi(ctx context.Context, asesorID, kompetensiID uint) (*models.AsesorKompetensi, error)
+	FindValidAt(ctx context.Context, at time.Time) ([]models.AsesorKompetensi, error)
+	FindValidByKompetensiAt(ctx context.Context, kompetensiID uint, at time.Time) ([]models.AsesorKompetensi, error)
+	FindByKompetensiID(ctx context.Context, kompetensiID uint) ([]models.AsesorKompetensi, error)
+}
+
+type asesorKompetensiRepository struct {
+	db *gorm.DB
+}
+
+func NewAsesorKompetensiRepository(db *gorm.DB) AsesorKompetensiRepository {
+	return &asesorKompetensiRepository{db: db}
+}
+
+func (r *asesorKompetensiRepository) Save(ctx context.Context, asesorKompetensi *models.AsesorKompetensi) error {
+	return r.db.WithContext(ctx).Omit("Asesor", "Kompetensi", "DokumenBukti").Save(asesorKompetensi).Error
+}
+
+func (r *asesorKompetensiRepository) Delete(ctx context.Context, asesorID, kompetensiID uint) error {
+	return r.db.WithContext(ctx).
+		Where("asesor_id = ? AND kompetensi_id = ?", asesorID, kompetensiID).
+		Delete(&models.AsesorKompetensi{}).Error
+}
+
+func (r *asesorKompetensiRepository) FindByAsesorID(ctx context.Context, asesorID uint) ([]models.AsesorKompetensi, error) {
+	var asesorKompetensi []models.AsesorKompetensi
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Preload("DokumenBukti").Where("asesor_id = ?", asesorID).Find(&asesorKompetensi).Error
+	if err != nil {
+		return nil, err
+	}
+	return asesorKompetensi, nil
+}
+
+func (r *asesorKompetensiRepository) FindByAsesorAndKompetensi(ctx context.Context, asesorID, kompetensiID uint) (*models.AsesorKompetensi, error) {
+	var asesorKompetensi models.AsesorKompetensi
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Preload("DokumenBukti").
+		Where("asesor_id = ? AND kompetensi_id = ?", asesorID, kompetensiID).
+		First(&asesorKompetensi).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asesorKompetensi, nil
+}
+
+func (r *asesorKompetensiRepository) FindValidAt(ctx context.Context, at time.Time) ([]models.AsesorKompetensi, error) {
+	var asesorKompetensi []models.AsesorKompetensi
+	err := r.validAt(ctx, at).Find(&asesorKompetensi).Error
+	if err != nil {
+		return nil, err
+	}
+	return asesorKompetensi, nil
+}
+
+// FindValidByKompetensiAt is FindValidAt limited to a single kompetensi.
+func (r *asesorKompetensiRepository) FindValidByKompetensiAt(ctx context.Context, kompetensiID uint, at time.Time) ([]models.AsesorKompetensi, error) {
+	var asesorKompetensi []models.AsesorKompetensi
+	err := r.validAt(ctx, at).
+		Where("asesor_kompetensi.kompetensi_id = ?", kompetensiID).
+		Find(&asesorKompetensi).Error
+	if err != nil {
+		return nil, err
+	}
+	return asesorKompetensi, nil
+}
+
+func (r *asesorKompetensiRepository) validAt(ctx context.Context, at time.Time) *gorm.DB {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	return r.db.WithContext(ctx).Preload("Asesor").
+		Joins("JOIN asesors ON asesors.id = asesor_kompetensi.asesor_id AND asesors.deleted_at IS NULL").
+		Where("asesor_kompetensi.berlaku_mulai <= ? AND asesor_kompetensi.berlaku_sampai >= ?", at, day)
+}
+
+// FindByKompetensiID lists the certifications held for a kompetensi by
+// asesors that have not been deleted.
+func (r *asesorKompetensiRepository) FindByKompetensiID(ctx context.Context, kompetensiID uint) ([]models.AsesorKompetensi, error) {
+	var asesorKompetensi []models.AsesorKompetensi
+	err := r.db.WithContext(ctx).Preload("Asesor").
+		Joins("JOIN asesors ON asesors.id = asesor_kompetensi.asesor_id AND asesors.deleted_at IS NULL").
+		Where("asesor_kompetensi.kompetensi_id = ?", kompetensiID).
+		Find(&asesorKompetensi).Error
+	if err != nil {
+		return nil, err
+	}
+	return asesorKompetensi, nil
+}
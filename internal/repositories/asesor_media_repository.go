@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AsesorMediaRepository interface {
+	Save(ctx context.Context, media *models.AsesorMedia) error
+	FindByAsesorAndJenis(ctx context.Context, asesorID uint, jenis string) (*models.AsesorMedia, error)
+}
+
+type asesorMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewAsesorMediaRepository(db *gorm.DB) AsesorMediaRepository {
+	return &asesorMediaRepository{db: db}
+}
+
+func (r *asesorMediaRepository) Save(ctx context.Context, media *models.AsesorMedia) error {
+	return r.db.WithContext(ctx).Omit("Dokumen", "Thumbnail").Save(media).Error
+}
+
+func (r *asesorMediaRepository) FindByAsesorAndJenis(ctx context.Context, asesorID uint, jenis string) (*models.AsesorMedia, error) {
+	var media models.AsesorMedia
+	err := r.db.WithContext(ctx).Where("asesor_id = ? AND jenis = ?", asesorID, jenis).First(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
@@ -1,18 +1,27 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"lsp-api/internal/models"
 
 	"gorm.io/gorm"
 )
 
 type AsesorRepository interface {
-	Create(asesor *models.Asesor) error
-	Update(asesor *models.Asesor) error
-	Delete(id uint) error
-	FindByID(id uint) (*models.Asesor, error)
-	FindAll() ([]models.Asesor, error)
-	FindByNoRegistrasi(noRegistrasi string) (*models.Asesor, error)
+	Create(ctx context.Context, asesor *models.Asesor) error
+	Update(ctx context.Context, asesor *models.Asesor) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*models.Asesor, error)
+	FindAll(ctx context.Context) ([]models.Asesor, error)
+	FindByKelengkapan(ctx context.Context, isComplete bool) ([]models.Asesor, error)
+	FindByNoRegistrasi(ctx context.Context, noRegistrasi string) (*models.Asesor, error)
+	FindByEmail(ctx context.Context, email string) (*models.Asesor, error)
+	FindByUserID(ctx context.Context, userID uint) (*models.Asesor, error)
+	FindLisensiExpiringBy(ctx context.Context, to time.Time) ([]models.Asesor, error)
+	UpdateKelengkapan(ctx context.Context, id uint, isComplete, manual bool) error
+	CountActive(ctx context.Context, at time.Time) (int64, error)
 }
 
 type asesorRepository struct {
@@ -23,41 +32,101 @@ func NewAsesorRepository(db *gorm.DB) AsesorRepository {
 	return &asesorRepository{db: db}
 }
 
-func (r *asesorRepository) Create(asesor *models.Asesor) error {
-	return r.db.Create(asesor).Error
+func (r *asesorRepository) Create(ctx context.Context, asesor *models.Asesor) error {
+	return r.db.WithContext(ctx).Create(asesor).Error
 }
 
-func (r *asesorRepository) Update(asesor *models.Asesor) error {
-	return r.db.Save(asesor).Error
+func (r *asesorRepository) Update(ctx context.Context, asesor *models.Asesor) error {
+	return r.db.WithContext(ctx).Save(asesor).Error
 }
 
-func (r *asesorRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Asesor{}, id).Error
+func (r *asesorRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Asesor{}, id).Error
 }
 
-func (r *asesorRepository) FindByID(id uint) (*models.Asesor, error) {
+func (r *asesorRepository) FindByID(ctx context.Context, id uint) (*models.Asesor, error) {
 	var asesor models.Asesor
-	err := r.db.Preload("Kompetensi").First(&asesor, id).Error
+	err := r.db.WithContext(ctx).Preload("Kompetensi").First(&asesor, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &asesor, nil
 }
 
-func (r *asesorRepository) FindAll() ([]models.Asesor, error) {
+func (r *asesorRepository) FindAll(ctx context.Context) ([]models.Asesor, error) {
 	var asesors []models.Asesor
-	err := r.db.Preload("Kompetensi").Find(&asesors).Error
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Find(&asesors).Error
 	if err != nil {
 		return nil, err
 	}
 	return asesors, nil
 }
 
-func (r *asesorRepository) FindByNoRegistrasi(noRegistrasi string) (*models.Asesor, error) {
+func (r *asesorRepository) FindByKelengkapan(ctx context.Context, isComplete bool) ([]models.Asesor, error) {
+	var asesors []models.Asesor
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Where("is_complete = ?", isComplete).Find(&asesors).Error
+	if err != nil {
+		return nil, err
+	}
+	return asesors, nil
+}
+
+func (r *asesorRepository) FindByNoRegistrasi(ctx context.Context, noRegistrasi string) (*models.Asesor, error) {
+	var asesor models.Asesor
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Where("no_registrasi = ?", noRegistrasi).First(&asesor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asesor, nil
+}
+
+func (r *asesorRepository) FindByEmail(ctx context.Context, email string) (*models.Asesor, error) {
 	var asesor models.Asesor
-	err := r.db.Preload("Kompetensi").Where("no_registrasi = ?", noRegistrasi).First(&asesor).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&asesor).Error
 	if err != nil {
 		return nil, err
 	}
 	return &asesor, nil
-}
\ No newline at end of file
+}
+
+func (r *asesorRepository) FindByUserID(ctx context.Context, userID uint) (*models.Asesor, error) {
+	var asesor models.Asesor
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Where("user_id = ?", userID).First(&asesor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asesor, nil
+}
+
+// FindLisensiExpiringBy lists asesors whose license expires on or before to,
+// including licenses that have already lapsed.
+func (r *asesorRepository) FindLisensiExpiringBy(ctx context.Context, to time.Time) ([]models.Asesor, error) {
+	var asesors []models.Asesor
+	err := r.db.WithContext(ctx).
+		Where("tanggal_kadaluarsa_lisensi <= ?", to).
+		Order("tanggal_kadaluarsa_lisensi ASC").
+		Find(&asesors).Error
+	if err != nil {
+		return nil, err
+	}
+	return asesors, nil
+}
+
+func (r *asesorRepository) UpdateKelengkapan(ctx context.Context, id uint, isComplete, manual bool) error {
+	return r.db.WithContext(ctx).Model(&models.Asesor{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_complete":        isComplete,
+		"kelengkapan_manual": manual,
+	}).Error
+}
+
+// CountActive counts asesors whose license is active and not expired at the
+// given time, matching Asesor.LisensiBerlaku.
+func (r *asesorRepository) CountActive(ctx context.Context, at time.Time) (int64, error) {
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Asesor{}).
+		Where("status_lisensi = ? AND tanggal_kadaluarsa_lisensi >= ?", models.StatusLisensiAktif, today).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type DokumenRepository interface {
+	Create(ctx context.Context, dokumen *models.Dokumen) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*models.Dokumen, error)
+	IsReferenced(ctx context.Context, id uint) (bool, error)
+}
+
+type dokumenRepository struct {
+	db *gorm.DB
+}
+
+func NewDokumenRepository(db *gorm.DB) DokumenRepository {
+	return &dokumenRepository{db: db}
+}
+
+func (r *dokumenRepository) Create(ctx context.Context, dokumen *models.Dokumen) error {
+	return r.db.WithContext(ctx).Create(dokumen).Error
+}
+
+func (r *dokumenRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Dokumen{}, id).Error
+}
+
+func (r *dokumenRepository) FindByID(ctx context.Context, id uint) (*models.Dokumen, error) {
+	var dokumen models.Dokumen
+	err := r.db.WithContext(ctx).First(&dokumen, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dokumen, nil
+}
+
+// IsReferenced reports whether an APL-01, APL-02 evidence, asesor license,
+// certification or asesor media still points at the dokumen.
+func (r *dokumenRepository) IsReferenced(ctx context.Context, id uint) (bool, error) {
+	db := r.db.WithContext(ctx)
+	references := []*gorm.DB{
+		db.Table("apl01_dokumen").Where("dokumen_id = ?", id),
+		db.Table("apl02_item_bukti").Where("dokumen_id = ?", id),
+		db.Model(&models.Asesor{}).Where("dokumen_lisensi_id = ?", id),
+		db.Model(&models.AsesorKompetensi{}).Where("dokumen_bukti_id = ?", id),
+		db.Model(&models.AsesorMedia{}).Where("dokumen_id = ? OR thumbnail_id = ?", id, id),
+	}
+
+	for _, query := range references {
+		var count int64
+		err := query.Count(&count).Error
+		if err != nil || count > 0 {
+			return count > 0, err
+		}
+	}
+	return false, nil
+}
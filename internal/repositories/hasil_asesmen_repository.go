@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HasilAsesmenRepository interface {
+	Create(ctx context.Context, hasil *models.HasilAsesmen) error
+	Update(ctx context.Context, hasil *models.HasilAsesmen) error
+	SignOff(ctx context.Context, id uint, at time.Time) error
+	FindByID(ctx context.Context, id uint) (*models.HasilAsesmen, error)
+	FindAll(ctx context.Context, asesiID, asesorID uint) ([]models.HasilAsesmen, error)
+}
+
+type hasilAsesmenRepository struct {
+	db *gorm.DB
+}
+
+func NewHasilAsesmenRepository(db *gorm.DB) HasilAsesmenRepository {
+	return &hasilAsesmenRepository{db: db}
+}
+
+func (r *hasilAsesmenRepository) Create(ctx context.Context, hasil *models.HasilAsesmen) error {
+	return r.db.WithContext(ctx).Omit("Jadwal", "Kompetensi", "Asesor", "Units.UnitKompetensi").Create(hasil).Error
+}
+
+// Update replaces the result and its unit verdicts, but only while the result
+// is still a draft. A signed-off result yields gorm.ErrRecordNotFound.
+func (r *hasilAsesmenRepository) Update(ctx context.Context, hasil *models.HasilAsesmen) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.HasilAsesmen
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ?", hasil.ID, models.StatusHasilDraft).
+			First(&current).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&current).Select("MetodeAsesmen", "Rekomendasi", "Catatan").Updates(hasil).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("hasil_asesmen_id = ?", hasil.ID).Delete(&models.HasilAsesmenUnit{}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range hasil.Units {
+			hasil.Units[i].ID = 0
+			hasil.Units[i].HasilAsesmenID = hasil.ID
+		}
+		if len(hasil.Units) == 0 {
+			return nil
+		}
+		return tx.Omit("UnitKompetensi").Create(&hasil.Units).Error
+	})
+}
+
+// SignOff locks a draft result. It yields gorm.ErrRecordNotFound when the
+// result was already signed off, so concurrent sign-offs cannot both succeed.
+func (r *hasilAsesmenRepository) SignOff(ctx context.Context, id uint, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.HasilAsesmen{}).
+		Where("id = ? AND status = ?", id, models.StatusHasilDraft).
+		Updates(map[string]interface{}{
+			"status":              models.StatusHasilDitandatangani,
+			"ditandatangani_pada": at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *hasilAsesmenRepository) FindByID(ctx context.Context, id uint) (*models.HasilAsesmen, error) {
+	var hasil models.HasilAsesmen
+	err := r.db.WithContext(ctx).
+		Preload("Kompetensi").
+		Preload("Asesor").
+		Preload("Units", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Units.UnitKompetensi").
+		First(&hasil, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hasil, nil
+}
+
+// FindAll lists results, optionally narrowed to one asesi or one asesor.
+// A zero ID means no filter on that column.
+func (r *hasilAsesmenRepository) FindAll(ctx context.Context, asesiID, asesorID uint) ([]models.HasilAsesmen, error) {
+	var hasil []models.HasilAsesmen
+	query := r.db.WithContext(ctx).Preload("Kompetensi").Preload("Asesor").Order("created_at DESC")
+	if asesiID != 0 {
+		query = query.Where("asesi_id = ?", asesiID)
+	}
+	if asesorID != 0 {
+		query = query.Where("asesor_id = ?", asesorID)
+	}
+	err := query.Find(&hasil).Error
+	if err != nil {
+		return nil, err
+	}
+	return hasil, nil
+}
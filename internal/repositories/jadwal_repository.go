@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type JadwalRepository interface {
+	Create(ctx context.Context, jadwal *models.JadwalAsesmen) error
+	FindByID(ctx context.Context, id uint) (*models.JadwalAsesmen, error)
+	FindAll(ctx context.Context) ([]models.JadwalAsesmen, error)
+	FindByAsesorID(ctx context.Context, asesorID uint) ([]models.JadwalAsesmen, error)
+	FindOverlappingForAsesors(ctx context.Context, asesorIDs []uint, from, to time.Time) ([]models.JadwalAsesmen, error)
+	FindPeserta(ctx context.Context, jadwalID uint) ([]models.JadwalPeserta, error)
+	FindPesertaByAPL01(ctx context.Context, jadwalID, apl01ID uint) (*models.JadwalPeserta, error)
+	AddAsesor(ctx context.Context, jadwal *models.JadwalAsesmen, asesor *models.Asesor) error
+	RemoveAsesor(ctx context.Context, jadwal *models.JadwalAsesmen, asesor *models.Asesor) error
+}
+
+type jadwalRepository struct {
+	db *gorm.DB
+}
+
+func NewJadwalRepository(db *gorm.DB) JadwalRepository {
+	return &jadwalRepository{db: db}
+}
+
+func (r *jadwalRepository) Create(ctx context.Context, jadwal *models.JadwalAsesmen) error {
+	return r.db.WithContext(ctx).Omit("Kompetensi", "TUK").Create(jadwal).Error
+}
+
+func (r *jadwalRepository) FindByID(ctx context.Context, id uint) (*models.JadwalAsesmen, error) {
+	var jadwal models.JadwalAsesmen
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Preload("TUK").Preload("Asesor").First(&jadwal, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &jadwal, nil
+}
+
+func (r *jadwalRepository) FindAll(ctx context.Context) ([]models.JadwalAsesmen, error) {
+	var jadwal []models.JadwalAsesmen
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Preload("TUK").Preload("Asesor").Order("tanggal_mulai ASC").Find(&jadwal).Error
+	if err != nil {
+		return nil, err
+	}
+	return jadwal, nil
+}
+
+func (r *jadwalRepository) FindByAsesorID(ctx context.Context, asesorID uint) ([]models.JadwalAsesmen, error) {
+	var jadwal []models.JadwalAsesmen
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Preload("TUK").
+		Joins("JOIN jadwal_asesor ON jadwal_asesor.jadwal_asesmen_id = jadwal_asesmen.id").
+		Where("jadwal_asesor.asesor_id = ?", asesorID).
+		Order("tanggal_mulai ASC").
+		Find(&jadwal).Error
+	if err != nil {
+		return nil, err
+	}
+	return jadwal, nil
+}
+
+// FindOverlappingForAsesors returns schedules assigned to any of the asesors
+// that overlap the half-open range from..to.
+func (r *jadwalRepository) FindOverlappingForAsesors(ctx context.Context, asesorIDs []uint, from, to time.Time) ([]models.JadwalAsesmen, error) {
+	var jadwal []models.JadwalAsesmen
+	err := r.db.WithContext(ctx).Preload("Asesor").
+		Where("tanggal_mulai < ? AND tanggal_selesai > ?", to, from).
+		Where("id IN (?)", r.db.WithContext(ctx).Table("jadwal_asesor").Select("jadwal_asesmen_id").Where("asesor_id IN ?", asesorIDs)).
+		Order("tanggal_mulai ASC").
+		Find(&jadwal).Error
+	if err != nil {
+		return nil, err
+	}
+	return jadwal, nil
+}
+
+func (r *jadwalRepository) FindPeserta(ctx context.Context, jadwalID uint) ([]models.JadwalPeserta, error) {
+	var peserta []models.JadwalPeserta
+	err := r.db.WithContext(ctx).Preload("Asesor").Where("jadwal_id = ?", jadwalID).Order("id ASC").Find(&peserta).Error
+	if err != nil {
+		return nil, err
+	}
+	return peserta, nil
+}
+
+func (r *jadwalRepository) FindPesertaByAPL01(ctx context.Context, jadwalID, apl01ID uint) (*models.JadwalPeserta, error) {
+	var peserta models.JadwalPeserta
+	err := r.db.WithContext(ctx).Where("jadwal_id = ? AND apl01_id = ?", jadwalID, apl01ID).First(&peserta).Error
+	if err != nil {
+		return nil, err
+	}
+	return &peserta, nil
+}
+
+func (r *jadwalRepository) AddAsesor(ctx context.Context, jadwal *models.JadwalAsesmen, asesor *models.Asesor) error {
+	return r.db.WithContext(ctx).Model(jadwal).Association("Asesor").Append(asesor)
+}
+
+// RemoveAsesor also releases the participants the asesor was assessing on
+// the jadwal.
+func (r *jadwalRepository) RemoveAsesor(ctx context.Context, jadwal *models.JadwalAsesmen, asesor *models.Asesor) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.JadwalPeserta{}).
+			Where("jadwal_id = ? AND asesor_id = ?", jadwal.ID, asesor.ID).
+			Update("asesor_id", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(jadwal).Association("Asesor").Delete(asesor)
+	})
+}
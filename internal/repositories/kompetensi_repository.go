@@ -1,16 +1,18 @@
 package repositories
 
 import (
+	"context"
+
 	"lsp-api/internal/models"
 
 	"gorm.io/gorm"
 )
 
 type KompetensiRepository interface {
-	Create(kompetensi *models.Kompetensi) error
-	FindByID(id uint) (*models.Kompetensi, error)
-	FindAll() ([]models.Kompetensi, error)
-	FindByIDs(ids []uint) ([]models.Kompetensi, error)
+	Create(ctx context.Context, kompetensi *models.Kompetensi) error
+	FindByID(ctx context.Context, id uint) (*models.Kompetensi, error)
+	FindAll(ctx context.Context) ([]models.Kompetensi, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Kompetensi, error)
 }
 
 type kompetensiRepository struct {
@@ -21,31 +23,31 @@ func NewKompetensiRepository(db *gorm.DB) KompetensiRepository {
 	return &kompetensiRepository{db: db}
 }
 
-func (r *kompetensiRepository) Create(kompetensi *models.Kompetensi) error {
-	return r.db.Create(kompetensi).Error
+func (r *kompetensiRepository) Create(ctx context.Context, kompetensi *models.Kompetensi) error {
+	return r.db.WithContext(ctx).Create(kompetensi).Error
 }
 
-func (r *kompetensiRepository) FindByID(id uint) (*models.Kompetensi, error) {
+func (r *kompetensiRepository) FindByID(ctx context.Context, id uint) (*models.Kompetensi, error) {
 	var kompetensi models.Kompetensi
-	err := r.db.First(&kompetensi, id).Error
+	err := r.db.WithContext(ctx).First(&kompetensi, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &kompetensi, nil
 }
 
-func (r *kompetensiRepository) FindAll() ([]models.Kompetensi, error) {
+func (r *kompetensiRepository) FindAll(ctx context.Context) ([]models.Kompetensi, error) {
 	var kompetensi []models.Kompetensi
-	err := r.db.Find(&kompetensi).Error
+	err := r.db.WithContext(ctx).Find(&kompetensi).Error
 	if err != nil {
 		return nil, err
 	}
 	return kompetensi, nil
 }
 
-func (r *kompetensiRepository) FindByIDs(ids []uint) ([]models.Kompetensi, error) {
+func (r *kompetensiRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Kompetensi, error) {
 	var kompetensi []models.Kompetensi
-	err := r.db.Where("id IN ?", ids).Find(&kompetensi).Error
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&kompetensi).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type KonflikKepentinganRepository interface {
+	Create(ctx context.Context, konflik *models.KonflikKepentingan) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*models.KonflikKepentingan, error)
+	FindByPair(ctx context.Context, asesorID, asesiID uint) ([]models.KonflikKepentingan, error)
+	FindAll(ctx context.Context, asesorID, asesiID uint) ([]models.KonflikKepentingan, error)
+}
+
+type konflikKepentinganRepository struct {
+	db *gorm.DB
+}
+
+func NewKonflikKepentinganRepository(db *gorm.DB) KonflikKepentinganRepository {
+	return &konflikKepentinganRepository{db: db}
+}
+
+// Create keeps the existing record when the same conflict was already
+// recorded for the pair.
+func (r *konflikKepentinganRepository) Create(ctx context.Context, konflik *models.KonflikKepentingan) error {
+	return r.db.WithContext(ctx).Omit("Asesor").Clauses(clause.OnConflict{DoNothing: true}).Create(konflik).Error
+}
+
+func (r *konflikKepentinganRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.KonflikKepentingan{}, id).Error
+}
+
+func (r *konflikKepentinganRepository) FindByID(ctx context.Context, id uint) (*models.KonflikKepentingan, error) {
+	var konflik models.KonflikKepentingan
+	err := r.db.WithContext(ctx).First(&konflik, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &konflik, nil
+}
+
+func (r *konflikKepentinganRepository) FindByPair(ctx context.Context, asesorID, asesiID uint) ([]models.KonflikKepentingan, error) {
+	return r.FindAll(ctx, asesorID, asesiID)
+}
+
+// FindAll lists conflicts, optionally narrowed to one asesor or one asesi.
+// A zero ID means no filter on that column.
+func (r *konflikKepentinganRepository) FindAll(ctx context.Context, asesorID, asesiID uint) ([]models.KonflikKepentingan, error) {
+	var konflik []models.KonflikKepentingan
+	query := r.db.WithContext(ctx).Order("created_at DESC")
+	if asesorID != 0 {
+		query = query.Where("asesor_id = ?", asesorID)
+	}
+	if asesiID != 0 {
+		query = query.Where("asesi_id = ?", asesiID)
+	}
+	err := query.Find(&konflik).Error
+	if err != nil {
+		return nil, err
+	}
+	return konflik, nil
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Periode values accepted by StatistikAsesor.
+const (
+	PeriodeBulan = "bulan"
+	PeriodeTahun = "tahun"
+	PeriodeSemua = "semua"
+)
+
+var periodeFormats = map[string]string{
+	PeriodeBulan: "'%Y-%m'",
+	PeriodeTahun: "'%Y'",
+}
+
+// LaporanRepository aggregates signed assessment results in the database so
+// reports never load individual rows.
+type LaporanRepository interface {
+	StatistikAsesor(ctx context.Context, from, to time.Time, periode string) ([]models.StatistikAsesor, error)
+	StatistikKompetensi(ctx context.Context, from, to time.Time) ([]models.StatistikKompetensi, error)
+	AsesorIdle(ctx context.Context, from, to time.Time) ([]models.AsesorIdle, error)
+	BarisBNSP(ctx context.Context, from, to time.Time) ([]models.BarisBNSP, error)
+}
+
+type laporanRepository struct {
+	db *gorm.DB
+}
+
+func NewLaporanRepository(db *gorm.DB) LaporanRepository {
+	return &laporanRepository{db: db}
+}
+
+// hasilColumns counts verdicts and averages the hours from the scheduled
+// start of the jadwal to the sign-off of the result.
+const hasilColumns = "COUNT(*) AS jumlah_asesmen, " +
+	"SUM(CASE WHEN hasil_asesmen.rekomendasi = '" + models.KeputusanKompeten + "' THEN 1 ELSE 0 END) AS jumlah_kompeten, " +
+	"SUM(CASE WHEN hasil_asesmen.rekomendasi = '" + models.KeputusanBelumKompeten + "' THEN 1 ELSE 0 END) AS jumlah_belum_kompeten, " +
+	"COALESCE(AVG(TIMESTAMPDIFF(MINUTE, jadwal_asesmen.tanggal_mulai, hasil_asesmen.ditandatangani_pada)) / 60, 0) AS rata_rata_penyelesaian_jam"
+
+// signedHasil selects results signed off in the half-open range [from, to).
+func (r *laporanRepository) signedHasil(ctx context.Context, from, to time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Table("hasil_asesmen").
+		Joins("JOIN jadwal_asesmen ON jadwal_asesmen.id = hasil_asesmen.jadwal_id AND jadwal_asesmen.deleted_at IS NULL").
+		Where("hasil_asesmen.status = ?", models.StatusHasilDitandatangani).
+		Where("hasil_asesmen.ditandatangani_pada >= ? AND hasil_asesmen.ditandatangani_pada < ?", from, to)
+}
+
+func (r *laporanRepository) StatistikAsesor(ctx context.Context, from, to time.Time, periode string) ([]models.StatistikAsesor, error) {
+	periodeColumn := "''"
+	if format, ok := periodeFormats[periode]; ok {
+		periodeColumn = "DATE_FORMAT(hasil_asesmen.ditandatangani_pada, " + format + ")"
+	}
+
+	var statistik []models.StatistikAsesor
+	err := r.signedHasil(ctx, from, to).
+		Select("hasil_asesmen.asesor_id, asesors.nama_lengkap, asesors.no_registrasi, " +
+			periodeColumn + " AS periode, " + hasilColumns).
+		Joins("JOIN asesors ON asesors.id = hasil_asesmen.asesor_id").
+		Group("hasil_asesmen.asesor_id, asesors.nama_lengkap, asesors.no_registrasi, periode").
+		Order("periode, asesors.nama_lengkap").
+		Scan(&statistik).Error
+	if err != nil {
+		return nil, err
+	}
+	return statistik, nil
+}
+
+func (r *laporanRepository) StatistikKompetensi(ctx context.Context, from, to time.Time) ([]models.StatistikKompetensi, error) {
+	var statistik []models.StatistikKompetensi
+	err := r.signedHasil(ctx, from, to).
+		Select("hasil_asesmen.kompetensi_id, kompetensis.kode, kompetensis.nama, " + hasilColumns).
+		Joins("JOIN kompetensis ON kompetensis.id = hasil_asesmen.kompetensi_id").
+		Group("hasil_asesmen.kompetensi_id, kompetensis.kode, kompetensis.nama").
+		Order("kompetensis.kode").
+		Scan(&statistik).Error
+	if err != nil {
+		return nil, err
+	}
+	return statistik, nil
+}
+
+// AsesorIdle lists asesors that were neither scheduled on a jadwal
+// overlapping [from, to) nor signed off an assessment result in it.
+func (r *laporanRepository) AsesorIdle(ctx context.Context, from, to time.Time) ([]models.AsesorIdle, error) {
+	scheduled := r.db.WithContext(ctx).Table("jadwal_asesor").
+		Select("1").
+		Joins("JOIN jadwal_asesmen ON jadwal_asesmen.id = jadwal_asesor.jadwal_asesmen_id AND jadwal_asesmen.deleted_at IS NULL").
+		Where("jadwal_asesor.asesor_id = asesors.id").
+		Where("jadwal_asesmen.tanggal_mulai < ? AND jadwal_asesmen.tanggal_selesai >= ?", to, from)
+
+	assessed := r.db.WithContext(ctx).Table("hasil_asesmen").
+		Select("1").
+		Where("hasil_asesmen.asesor_id = asesors.id").
+		Where("hasil_asesmen.ditandatangani_pada >= ? AND hasil_asesmen.ditandatangani_pada < ?", from, to)
+
+	var idle []models.AsesorIdle
+	err := r.db.WithContext(ctx).Model(&models.Asesor{}).
+		Select("asesors.id AS asesor_id, asesors.nama_lengkap, asesors.no_registrasi, asesors.status_lisensi, "+
+			"(SELECT MAX(hasil_asesmen.ditandatangani_pada) FROM hasil_asesmen WHERE hasil_asesmen.asesor_id = asesors.id) AS terakhir_asesmen").
+		Where("NOT EXISTS (?)", scheduled).
+		Where("NOT EXISTS (?)", assessed).
+		Order("asesors.nama_lengkap").
+		Scan(&idle).Error
+	if err != nil {
+		return nil, err
+	}
+	return idle, nil
+}
+
+// BarisBNSP flattens every result signed off in [from, to) with the asesi,
+// skema, TUK, asesor and certificate data the regulator asks for.
+func (r *laporanRepository) BarisBNSP(ctx context.Context, from, to time.Time) ([]models.BarisBNSP, error) {
+	var baris []models.BarisBNSP
+	err := r.signedHasil(ctx, from, to).
+		Select("hasil_asesmen.id AS hasil_asesmen_id, " +
+			"apl01.nama_lengkap AS nama_asesi, apl01.nik, apl01.tempat_lahir, apl01.tanggal_lahir, " +
+			"apl01.jenis_kelamin, apl01.kebangsaan, apl01.alamat_rumah, apl01.kode_pos, apl01.no_telepon, " +
+			"apl01.email, apl01.pendidikan_terakhir, apl01.nama_institusi, apl01.jabatan, " +
+			"kompetensis.kode AS kode_skema, kompetensis.nama AS nama_skema, " +
+			"COALESCE(tuk.kode, '') AS kode_tuk, COALESCE(tuk.nama, '') AS nama_tuk, " +
+			"asesors.no_registrasi AS no_registrasi_asesor, asesors.nama_lengkap AS nama_asesor, " +
+			"jadwal_asesmen.tanggal_mulai AS tanggal_uji, hasil_asesmen.rekomendasi, " +
+			"COALESCE(sertifikat.nomor_sertifikat, '') AS nomor_sertifikat, sertifikat.tanggal_terbit").
+		Joins("JOIN apl01 ON apl01.id = hasil_asesmen.apl01_id").
+		Joins("JOIN kompetensis ON kompetensis.id = hasil_asesmen.kompetensi_id").
+		Joins("JOIN asesors ON asesors.id = hasil_asesmen.asesor_id").
+		Joins("LEFT JOIN tuk ON tuk.id = jadwal_asesmen.tuk_id").
+		Joins("LEFT JOIN sertifikat ON sertifikat.hasil_asesmen_id = hasil_asesmen.id").
+		Order("hasil_asesmen.ditandatangani_pada, hasil_asesmen.id").
+		Scan(&baris).Error
+	if err != nil {
+		return nil, err
+	}
+	return baris, nil
+}
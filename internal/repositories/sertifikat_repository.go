@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SertifikatRepository interface {
+	Issue(ctx context.Context, sertifikat *models.Sertifikat, scope string, nomor func(seq uint64) string) error
+	Renew(ctx context.Context, prior *models.Sertifikat, riwayat *models.SertifikatRiwayat, renewal *models.Sertifikat, scope string, nomor func(seq uint64) string) error
+	ChangeStatus(ctx context.Context, sertifikat *models.Sertifikat, riwayat *models.SertifikatRiwayat) error
+	FindByID(ctx context.Context, id uint) (*models.Sertifikat, error)
+	FindByNomor(ctx context.Context, nomor string) (*models.Sertifikat, error)
+	FindByHasilAsesmenID(ctx context.Context, hasilAsesmenID uint) (*models.Sertifikat, error)
+	FindBySebelumnyaID(ctx context.Context, sebelumnyaID uint) (*models.Sertifikat, error)
+	FindAll(ctx context.Context, asesiID uint) ([]models.Sertifikat, error)
+}
+
+type sertifikatRepository struct {
+	db *gorm.DB
+}
+
+func NewSertifikatRepository(db *gorm.DB) SertifikatRepository {
+	return &sertifikatRepository{db: db}
+}
+
+// Issue allocates the next sequence of the scope and stores the certificate in
+// the same transaction.
+func (r *sertifikatRepository) Issue(ctx context.Context, sertifikat *models.Sertifikat, scope string, nomor func(seq uint64) string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.create(tx, sertifikat, scope, nomor)
+	})
+}
+
+// Renew marks the prior certificate as renewed and issues its replacement
+// atomically. It yields gorm.ErrRecordNotFound when the prior certificate is
+// no longer active, so it cannot be renewed twice.
+func (r *sertifikatRepository) Renew(ctx context.Context, prior *models.Sertifikat, riwayat *models.SertifikatRiwayat, renewal *models.Sertifikat, scope string, nomor func(seq uint64) string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := r.updateStatus(tx, prior, riwayat)
+		if err != nil {
+			return err
+		}
+		return r.create(tx, renewal, scope, nomor)
+	})
+}
+
+// ChangeStatus persists the new status and its history entry atomically. It
+// yields gorm.ErrRecordNotFound when the status was changed concurrently.
+func (r *sertifikatRepository) ChangeStatus(ctx context.Context, sertifikat *models.Sertifikat, riwayat *models.SertifikatRiwayat) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.updateStatus(tx, sertifikat, riwayat)
+	})
+}
+
+func (r *sertifikatRepository) updateStatus(tx *gorm.DB, sertifikat *models.Sertifikat, riwayat *models.SertifikatRiwayat) error {
+	result := tx.Model(&models.Sertifikat{}).
+		Where("id = ? AND status = ?", sertifikat.ID, riwayat.DariStatus).
+		Updates(map[string]interface{}{
+			"status": sertifikat.Status,
+			"alasan": sertifikat.Alasan,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return tx.Create(riwayat).Error
+}
+
+// create takes the next number of the scope. The counter row stays locked
+// until commit, so concurrent issuers are serialized and a failed insert rolls
+// the sequence back instead of leaving a gap.
+func (r *sertifikatRepository) create(tx *gorm.DB, sertifikat *models.Sertifikat, scope string, nomor func(seq uint64) string) error {
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.SertifikatCounter{Scope: scope}).Error
+	if err != nil {
+		return err
+	}
+
+	var counter models.SertifikatCounter
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("scope = ?", scope).
+		First(&counter).Error
+	if err != nil {
+		return err
+	}
+
+	counter.Nilai++
+	err = tx.Model(&counter).Update("nilai", counter.Nilai).Error
+	if err != nil {
+		return err
+	}
+
+	sertifikat.NomorSertifikat = nomor(counter.Nilai)
+	return tx.Omit("Kompetensi", "Sebelumnya", "Riwayat").Create(sertifikat).Error
+}
+
+func (r *sertifikatRepository) FindByID(ctx context.Context, id uint) (*models.Sertifikat, error) {
+	var sertifikat models.Sertifikat
+	err := r.db.WithContext(ctx).
+		Preload("Kompetensi").
+		Preload("Sebelumnya").
+		Preload("Riwayat", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&sertifikat, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sertifikat, nil
+}
+
+func (r *sertifikatRepository) FindByNomor(ctx context.Context, nomor string) (*models.Sertifikat, error) {
+	var sertifikat models.Sertifikat
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Where("nomor_sertifikat = ?", nomor).First(&sertifikat).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sertifikat, nil
+}
+
+func (r *sertifikatRepository) FindByHasilAsesmenID(ctx context.Context, hasilAsesmenID uint) (*models.Sertifikat, error) {
+	var sertifikat models.Sertifikat
+	err := r.db.WithContext(ctx).Where("hasil_asesmen_id = ?", hasilAsesmenID).First(&sertifikat).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sertifikat, nil
+}
+
+func (r *sertifikatRepository) FindBySebelumnyaID(ctx context.Context, sebelumnyaID uint) (*models.Sertifikat, error) {
+	var sertifikat models.Sertifikat
+	err := r.db.WithContext(ctx).Where("sebelumnya_id = ?", sebelumnyaID).First(&sertifikat).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sertifikat, nil
+}
+
+// FindAll lists certificates, optionally narrowed to one asesi. A zero ID
+// means no filter.
+func (r *sertifikatRepository) FindAll(ctx context.Context, asesiID uint) ([]models.Sertifikat, error) {
+	var sertifikat []models.Sertifikat
+	query := r.db.WithContext(ctx).Preload("Kompetensi").Order("tanggal_terbit DESC, id DESC")
+	if asesiID != 0 {
+		query = query.Where("asesi_id = ?", asesiID)
+	}
+	err := query.Find(&sertifikat).Error
+	if err != nil {
+		return nil, err
+	}
+	return sertifikat, nil
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type TUKRepository interface {
+	Create(ctx context.Context, tuk *models.TUK) error
+	Update(ctx context.Context, tuk *models.TUK) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*models.TUK, error)
+	FindByKode(ctx context.Context, kode string) (*models.TUK, error)
+	FindAll(ctx context.Context) ([]models.TUK, error)
+}
+
+type tukRepository struct {
+	db *gorm.DB
+}
+
+func NewTUKRepository(db *gorm.DB) TUKRepository {
+	return &tukRepository{db: db}
+}
+
+func (r *tukRepository) Create(ctx context.Context, tuk *models.TUK) error {
+	return r.db.WithContext(ctx).Omit("Kompetensi.*").Create(tuk).Error
+}
+
+// Update saves the TUK and replaces the kompetensi allowed at it.
+func (r *tukRepository) Update(ctx context.Context, tuk *models.TUK) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Kompetensi").Save(tuk).Error; err != nil {
+			return err
+		}
+		return tx.Model(tuk).Omit("Kompetensi.*").Association("Kompetensi").Replace(tuk.Kompetensi)
+	})
+}
+
+func (r *tukRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.TUK{}, id).Error
+}
+
+func (r *tukRepository) FindByID(ctx context.Context, id uint) (*models.TUK, error) {
+	var tuk models.TUK
+	err := r.db.WithContext(ctx).Preload("Kompetensi").First(&tuk, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tuk, nil
+}
+
+func (r *tukRepository) FindByKode(ctx context.Context, kode string) (*models.TUK, error) {
+	var tuk models.TUK
+	err := r.db.WithContext(ctx).Where("kode = ?", kode).First(&tuk).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tuk, nil
+}
+
+func (r *tukRepository) FindAll(ctx context.Context) ([]models.TUK, error) {
+	var tuk []models.TUK
+	err := r.db.WithContext(ctx).Preload("Kompetensi").Order("nama ASC").Find(&tuk).Error
+	if err != nil {
+		return nil, err
+	}
+	return tuk, nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type UnitKompetensiRepository interface {
+	Create(ctx context.Context, unit *models.UnitKompetensi) error
+	Delete(ctx context.Context, id uint) error
+	IsReferenced(ctx context.Context, id uint) (bool, error)
+	FindByID(ctx context.Context, id uint) (*models.UnitKompetensi, error)
+	FindByKompetensiID(ctx context.Context, kompetensiID uint) ([]models.UnitKompetensi, error)
+}
+
+type unitKompetensiRepository struct {
+	db *gorm.DB
+}
+
+func NewUnitKompetensiRepository(db *gorm.DB) UnitKompetensiRepository {
+	return &unitKompetensiRepository{db: db}
+}
+
+func (r *unitKompetensiRepository) Create(ctx context.Context, unit *models.UnitKompetensi) error {
+	return r.db.WithContext(ctx).Create(unit).Error
+}
+
+func (r *unitKompetensiRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		elemenIDs := tx.Model(&models.Elemen{}).Select("id").Where("unit_kompetensi_id = ?", id)
+		if err := tx.Where("elemen_id IN (?)", elemenIDs).Delete(&models.KriteriaUnjukKerja{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("unit_kompetensi_id = ?", id).Delete(&models.Elemen{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.UnitKompetensi{}, id).Error
+	})
+}
+
+// IsReferenced reports whether an APL-02 item answers one of the unit's
+// elemen or a hasil asesmen records a decision on the unit.
+func (r *unitKompetensiRepository) IsReferenced(ctx context.Context, id uint) (bool, error) {
+	db := r.db.WithContext(ctx)
+	elemenIDs := db.Model(&models.Elemen{}).Select("id").Where("unit_kompetensi_id = ?", id)
+
+	var count int64
+	err := db.Model(&models.APL02Item{}).Where("elemen_id IN (?)", elemenIDs).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = db.Model(&models.HasilAsesmenUnit{}).Where("unit_kompetensi_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *unitKompetensiRepository) FindByID(ctx context.Context, id uint) (*models.UnitKompetensi, error) {
+	var unit models.UnitKompetensi
+	err := r.preloadStructure(r.db.WithContext(ctx)).First(&unit, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &unit, nil
+}
+
+func (r *unitKompetensiRepository) FindByKompetensiID(ctx context.Context, kompetensiID uint) ([]models.UnitKompetensi, error) {
+	var units []models.UnitKompetensi
+	err := r.preloadStructure(r.db.WithContext(ctx)).
+		Where("kompetensi_id = ?", kompetensiID).
+		Order("urutan ASC, id ASC").
+		Find(&units).Error
+	if err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+func (r *unitKompetensiRepository) preloadStructure(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Elemen", func(db *gorm.DB) *gorm.DB { return db.Order("urutan ASC, id ASC") }).
+		Preload("Elemen.KriteriaUnjukKerja", func(db *gorm.DB) *gorm.DB { return db.Order("urutan ASC, id ASC") })
+}
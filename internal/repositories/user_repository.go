@@ -1,14 +1,19 @@
 package repositories
 
 import (
+	"context"
+
 	"lsp-api/internal/models"
 
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	Create(user *models.User) error
-	FindByEmail(email string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+	FindByCalendarToken(ctx context.Context, token string) (*models.User, error)
+	UpdateCalendarToken(ctx context.Context, id uint, token string) error
 }
 
 type userRepository struct {
@@ -19,15 +24,37 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) Create(user *models.User) error {
-	return r.db.Create(user).Error
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
 }
 
-func (r *userRepository) FindByEmail(email string) (*models.User, error) {
+func (r *userRepository) FindByCalendarToken(ctx context.Context, token string) (*models.User, error) {
 	var user models.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("calendar_token = ?", token).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
-}
\ No newline at end of file
+}
+
+func (r *userRepository) UpdateCalendarToken(ctx context.Context, id uint, token string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("calendar_token", token).Error
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"lsp-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UsulanPenugasanRepository interface {
+	Create(ctx context.Context, usulan *models.UsulanPenugasan) error
+	FindByID(ctx context.Context, id uint) (*models.UsulanPenugasan, error)
+	FindByJadwalID(ctx context.Context, jadwalID uint) ([]models.UsulanPenugasan, error)
+	UpdateItem(ctx context.Context, item *models.UsulanPenugasanItem) error
+	Accept(ctx context.Context, usulan *models.UsulanPenugasan, acceptedBy uint, at time.Time, checkPeserta func(count int64) error) error
+}
+
+type usulanPenugasanRepository struct {
+	db *gorm.DB
+}
+
+func NewUsulanPenugasanRepository(db *gorm.DB) UsulanPenugasanRepository {
+	return &usulanPenugasanRepository{db: db}
+}
+
+func (r *usulanPenugasanRepository) Create(ctx context.Context, usulan *models.UsulanPenugasan) error {
+	return r.db.WithContext(ctx).Omit("Items.Asesor").Create(usulan).Error
+}
+
+func (r *usulanPenugasanRepository) FindByID(ctx context.Context, id uint) (*models.UsulanPenugasan, error) {
+	var usulan models.UsulanPenugasan
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Asesor").
+		First(&usulan, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &usulan, nil
+}
+
+func (r *usulanPenugasanRepository) FindByJadwalID(ctx context.Context, jadwalID uint) ([]models.UsulanPenugasan, error) {
+	var usulan []models.UsulanPenugasan
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Asesor").
+		Where("jadwal_id = ?", jadwalID).
+		Order("created_at DESC").
+		Find(&usulan).Error
+	if err != nil {
+		return nil, err
+	}
+	return usulan, nil
+}
+
+func (r *usulanPenugasanRepository) UpdateItem(ctx context.Context, item *models.UsulanPenugasanItem) error {
+	return r.db.WithContext(ctx).Model(item).Select("AsesorID", "Catatan").Updates(item).Error
+}
+
+// Accept turns the proposal into jadwal participants and adds the proposed
+// asesors to the jadwal. It yields gorm.ErrRecordNotFound when the proposal
+// was accepted concurrently. checkPeserta gets the number of participants
+// once the proposal is applied and rolls it back by returning an error; the
+// jadwal stays locked meanwhile so concurrent acceptances are counted in turn.
+func (r *usulanPenugasanRepository) Accept(ctx context.Context, usulan *models.UsulanPenugasan, acceptedBy uint, at time.Time, checkPeserta func(count int64) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&models.JadwalAsesmen{}, usulan.JadwalID).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.UsulanPenugasan{}).
+			Where("id = ? AND status = ?", usulan.ID, models.StatusUsulanDraft).
+			Updates(map[string]interface{}{
+				"status":        models.StatusUsulanDiterima,
+				"diterima_oleh": acceptedBy,
+				"diterima_pada": at,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		jadwal := &models.JadwalAsesmen{ID: usulan.JadwalID}
+		added := make(map[uint]bool)
+		for _, item := range usulan.Items {
+			peserta := &models.JadwalPeserta{
+				JadwalID: usulan.JadwalID,
+				APL01ID:  item.APL01ID,
+				AsesiID:  item.AsesiID,
+				AsesorID: item.AsesorID,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "jadwal_id"}, {Name: "apl01_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"asesor_id", "updated_at"}),
+			}).Create(peserta).Error
+			if err != nil {
+				return err
+			}
+
+			if item.AsesorID == nil || added[*item.AsesorID] {
+				continue
+			}
+			added[*item.AsesorID] = true
+
+			err = tx.Model(jadwal).Omit("Asesor.*").Association("Asesor").Append(&models.Asesor{ID: *item.AsesorID})
+			if err != nil {
+				return err
+			}
+		}
+
+		var count int64
+		err = tx.Model(&models.JadwalPeserta{}).Where("jadwal_id = ?", usulan.JadwalID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		return checkPeserta(count)
+	})
+}
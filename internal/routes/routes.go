@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"lsp-api/internal/controllers"
+	"lsp-api/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controllers holds every controller mounted under /api/v1.
+type Controllers struct {
+	Auth               *controllers.AuthController
+	Asesor             *controllers.AsesorController
+	Dokumen            *controllers.DokumenController
+	AsesorKompetensi   *controllers.AsesorKompetensiController
+	AsesorMedia        *controllers.AsesorMediaController
+	Kompetensi         *controllers.KompetensiController
+	Jadwal             *controllers.JadwalController
+	TUK                *controllers.TUKController
+	Ketersediaan       *controllers.KetersediaanController
+	Penugasan          *controllers.PenugasanController
+	KonflikKepentingan *controllers.KonflikKepentinganController
+	APL01              *controllers.APL01Controller
+	APL02              *controllers.APL02Controller
+	HasilAsesmen       *controllers.HasilAsesmenController
+	Sertifikat         *controllers.SertifikatController
+	Laporan            *controllers.LaporanController
+}
+
+// Register mounts the API together with its JWK Set, its OpenAPI document and
+// the Swagger UI that renders it.
+func Register(router *gin.Engine, authMiddleware gin.HandlerFunc, c *Controllers) {
+	apiV1 := router.Group("/api/v1")
+	{
+		// Register auth routes
+		c.Auth.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register asesor routes
+		c.Asesor.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register asesor kompetensi certification routes
+		c.AsesorKompetensi.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register asesor photo and signature routes
+		c.AsesorMedia.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register kompetensi routes
+		c.Kompetensi.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register jadwal routes
+		c.Jadwal.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register TUK routes
+		c.TUK.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register asesor availability, free slot and calendar routes
+		c.Ketersediaan.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register jadwal participant and assignment proposal routes
+		c.Penugasan.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register conflict of interest routes
+		c.KonflikKepentingan.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register dokumen routes
+		c.Dokumen.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register APL-01 routes
+		c.APL01.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register APL-02 routes
+		c.APL02.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register assessment result routes
+		c.HasilAsesmen.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register sertifikat routes
+		c.Sertifikat.RegisterRoutes(apiV1, authMiddleware)
+
+		// Register reporting routes
+		c.Laporan.RegisterRoutes(apiV1, authMiddleware)
+	}
+	// Publish the token verification keys
+	router.GET("/.well-known/jwks.json", c.Auth.JWKS)
+
+	// Certificate verification is public and kept short, since the link is
+	// printed as a QR code on every certificate
+	router.GET("/verify/:nomor_sertifikat", c.Sertifikat.VerifySertifikat)
+
+	// Register API documentation routes
+	router.GET("/openapi.json", openapi.Handler(Document()))
+	router.GET("/docs/*filepath", openapi.SwaggerUI("/docs", "/openapi.json"))
+}
@@ -0,0 +1,146 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"lsp-api/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func registeredRoutes(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	// Handlers are never invoked, so controllers without services are
+	// enough to collect the routes
+	router := gin.New()
+	Register(router, func(*gin.Context) {}, &Controllers{})
+	return router
+}
+
+func TestOperationsMatchRegisteredRoutes(t *testing.T) {
+	router := registeredRoutes(t)
+
+	registered := map[string]bool{}
+	for _, route := range router.Routes() {
+		if strings.HasPrefix(route.Path, "/api/") || strings.HasPrefix(route.Path, "/verify/") {
+			registered[route.Method+" "+route.Path] = true
+		}
+	}
+
+	documented := map[string]bool{}
+	for _, op := range Operations() {
+		key := op.Method + " " + op.Path
+		if documented[key] {
+			t.Errorf("%s is documented twice", key)
+		}
+		documented[key] = true
+	}
+
+	var missing, stale []string
+	for key := range registered {
+		if !documented[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+
+	for _, key := range missing {
+		t.Errorf("route %s is not in the OpenAPI document", key)
+	}
+	for _, key := range stale {
+		t.Errorf("OpenAPI document lists %s, which is not registered", key)
+	}
+}
+
+func TestDocumentReferencesResolve(t *testing.T) {
+	router := registeredRoutes(t)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json returned %d", recorder.Code)
+	}
+
+	var document map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &document)
+	if err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+
+	components := document["components"].(map[string]any)["schemas"].(map[string]any)
+
+	var walk func(node any)
+	walk = func(node any) {
+		switch value := node.(type) {
+		case map[string]any:
+			if ref, ok := value["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/components/schemas/")
+				if _, ok := components[name]; !ok {
+					t.Errorf("unresolved reference %s", ref)
+				}
+			}
+			for _, child := range value {
+				walk(child)
+			}
+		case []any:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(document)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "/openapi.json") {
+		t.Errorf("Swagger UI initializer does not point at /openapi.json")
+	}
+}
+
+func TestSwaggerUIReplacesAPIContentSecurityPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.SecurityHeaders(0))
+	Register(router, func(*gin.Context) {}, &Controllers{})
+
+	tests := []struct {
+		path      string
+		wantPart  string
+		wantExact bool
+	}{
+		{path: "/openapi.json", wantPart: "default-src 'none'; frame-ancestors 'none'", wantExact: true},
+		{path: "/docs/index.html", wantPart: "script-src 'self'"},
+		{path: "/docs/swagger-initializer.js", wantPart: "script-src 'self'"},
+	}
+
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		policies := recorder.Header().Values("Content-Security-Policy")
+		if len(policies) != 1 {
+			t.Errorf("%s: %d Content-Security-Policy headers, want 1", tt.path, len(policies))
+			continue
+		}
+		if tt.wantExact && policies[0] != tt.wantPart || !strings.Contains(policies[0], tt.wantPart) {
+			t.Errorf("%s: Content-Security-Policy %q, want %q", tt.path, policies[0], tt.wantPart)
+		}
+		if !strings.Contains(policies[0], "frame-ancestors 'none'") {
+			t.Errorf("%s: policy allows framing", tt.path)
+		}
+	}
+}
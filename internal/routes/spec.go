@@ -0,0 +1,292 @@
+package routes
+
+import (
+	"net/http"
+
+	"lsp-api/internal/bnsp"
+	"lsp-api/internal/controllers"
+	"lsp-api/internal/models"
+	"lsp-api/internal/openapi"
+	"lsp-api/internal/services"
+	"lsp-api/internal/utils"
+)
+
+var (
+	admin  = []string{models.RoleAdmin}
+	asesor = []string{models.RoleAsesor}
+	asesi  = []string{models.RoleAsesi}
+)
+
+var rangeQuery = []openapi.Param{
+	{Name: "from", Description: "First day included, YYYY-MM-DD"},
+	{Name: "to", Description: "Last day included, YYYY-MM-DD"},
+}
+
+type calendarURL struct {
+	URL string `json:"url"`
+}
+
+type bnspValidasi struct {
+	Valid   bool           `json:"valid"`
+	Masalah []bnsp.Masalah `json:"masalah"`
+}
+
+// Document builds the OpenAPI document of every route mounted by Register.
+func Document() map[string]any {
+	return openapi.Build(openapi.Info{
+		Title:       "LSP API",
+		Version:     "1.0.0",
+		Description: "Every JSON response is wrapped in utils.Response.",
+	}, utils.Response{}, Operations())
+}
+
+// Operations describes every route mounted by Register. A test fails when
+// the two drift apart.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		// Auth
+		{Method: http.MethodPost, Path: "/api/v1/auth/register", Tag: "auth", Summary: "Create another admin account",
+			Auth: true, Roles: admin, Request: controllers.RegisterRequest{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/auth/register/asesi", Tag: "auth", Summary: "Register an asesi account",
+			Request: controllers.RegisterRequest{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: "auth", Summary: "Log in and receive a bearer token",
+			Request: controllers.LoginRequest{}, Response: controllers.LoginResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/logout", Tag: "auth", Summary: "Log out on the client; the token stays valid until it expires",
+			Auth: true},
+
+		// Asesor
+		{Method: http.MethodPost, Path: "/api/v1/asesors/", Tag: "asesor", Summary: "Create an asesor",
+			Auth: true, Roles: admin, Request: controllers.CreateAsesorRequest{}, Status: http.StatusCreated, Response: models.Asesor{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/", Tag: "asesor", Summary: "List asesors",
+			Auth: true, Roles: admin, Response: []models.Asesor{},
+			Query: []openapi.Param{{Name: "is_complete", Description: "Only asesors with this completeness flag", Type: "boolean"}}},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id", Tag: "asesor", Summary: "Get an asesor",
+			Auth: true, Roles: admin, Response: models.Asesor{}},
+		{Method: http.MethodPut, Path: "/api/v1/asesors/:id", Tag: "asesor", Summary: "Update an asesor",
+			Auth: true, Roles: admin, Request: controllers.UpdateAsesorRequest{}, Response: models.Asesor{}},
+		{Method: http.MethodDelete, Path: "/api/v1/asesors/:id", Tag: "asesor", Summary: "Delete an asesor",
+			Auth: true, Roles: admin},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/registrasi/:no_registrasi", Tag: "asesor", Summary: "Find an asesor by registration number",
+			Auth: true, Roles: admin, Response: models.Asesor{}},
+		{Method: http.MethodPost, Path: "/api/v1/asesors/:id/invitations", Tag: "asesor", Summary: "Invite an asesor to create an account",
+			Auth: true, Roles: admin, Status: http.StatusCreated, Response: models.AsesorInvitation{}},
+		{Method: http.MethodPut, Path: "/api/v1/asesors/:id/lisensi", Tag: "asesor", Summary: "Update the asesor license",
+			Auth: true, Roles: admin, Request: controllers.UpdateLisensiRequest{}, Response: models.Asesor{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/lisensi/expiring", Tag: "asesor", Summary: "List asesors whose license has expired or expires soon",
+			Auth: true, Roles: admin, Response: []models.Asesor{},
+			Query: []openapi.Param{{Name: "days", Description: "Look-ahead window in days, 30 by default", Type: "integer"}}},
+		{Method: http.MethodPut, Path: "/api/v1/asesors/:id/lisensi/dokumen", Tag: "asesor", Summary: "Upload the license document",
+			Auth: true, Roles: admin, Files: []string{"file"}, Response: models.Asesor{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id/lisensi/dokumen", Tag: "asesor", Summary: "Download the license document",
+			Auth: true, Roles: admin, ContentType: "application/octet-stream"},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id/completeness", Tag: "asesor", Summary: "Get the completeness breakdown",
+			Auth: true, Roles: admin, Response: services.KelengkapanAsesor{}},
+		{Method: http.MethodPatch, Path: "/api/v1/asesors/:id/completeness", Tag: "asesor", Summary: "Set or clear the manual completeness flag",
+			Auth: true, Roles: admin, Request: controllers.UpdateKelengkapanRequest{}, Response: services.KelengkapanAsesor{}},
+		{Method: http.MethodPost, Path: "/api/v1/asesor/invitations/accept", Tag: "asesor", Summary: "Accept an invitation and create the asesor account",
+			Request: controllers.AcceptInvitationRequest{}, Status: http.StatusCreated, Response: models.User{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesor/me", Tag: "asesor", Summary: "Get the logged in asesor",
+			Auth: true, Roles: asesor, Response: models.Asesor{}},
+		{Method: http.MethodPut, Path: "/api/v1/asesor/me", Tag: "asesor", Summary: "Update own contact details",
+			Auth: true, Roles: asesor, Request: controllers.UpdateOwnContactRequest{}, Response: models.Asesor{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesor/me/kompetensi", Tag: "asesor", Summary: "List own kompetensi",
+			Auth: true, Roles: asesor, Response: []models.Kompetensi{}},
+
+		// Asesor certifications and media
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id/kompetensi", Tag: "asesor", Summary: "List the asesor's kompetensi certifications",
+			Auth: true, Roles: admin, Response: []models.AsesorKompetensi{}},
+		{Method: http.MethodPut, Path: "/api/v1/asesors/:id/kompetensi/:kompetensi_id", Tag: "asesor", Summary: "Save a kompetensi certification",
+			Auth: true, Roles: admin, Request: controllers.SaveSertifikasiRequest{}, Response: models.AsesorKompetensi{}},
+		{Method: http.MethodDelete, Path: "/api/v1/asesors/:id/kompetensi/:kompetensi_id", Tag: "asesor", Summary: "Remove a kompetensi certification",
+			Auth: true, Roles: admin},
+		{Method: http.MethodPut, Path: "/api/v1/asesors/:id/kompetensi/:kompetensi_id/dokumen", Tag: "asesor", Summary: "Upload the certification evidence",
+			Auth: true, Roles: admin, Files: []string{"file"}, Response: models.AsesorKompetensi{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id/kompetensi/:kompetensi_id/dokumen", Tag: "asesor", Summary: "Download the certification evidence",
+			Auth: true, Roles: admin, ContentType: "application/octet-stream"},
+		{Method: http.MethodPut, Path: "/api/v1/asesors/:id/photo", Tag: "asesor", Summary: "Upload the asesor photo",
+			Auth: true, Roles: admin, Files: []string{"file"}, Response: models.AsesorMedia{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id/photo", Tag: "asesor", Summary: "Get the asesor photo",
+			Auth: true, Roles: admin, ContentType: "image/*",
+			Query: []openapi.Param{{Name: "variant", Description: "thumbnail for the reduced image"}}},
+		{Method: http.MethodPut, Path: "/api/v1/asesors/:id/signature", Tag: "asesor", Summary: "Upload the asesor signature",
+			Auth: true, Roles: admin, Files: []string{"file"}, Response: models.AsesorMedia{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id/signature", Tag: "asesor", Summary: "Get the asesor signature",
+			Auth: true, Roles: admin, ContentType: "image/*",
+			Query: []openapi.Param{{Name: "variant", Description: "thumbnail for the reduced image"}}},
+
+		// Availability and calendar
+		{Method: http.MethodGet, Path: "/api/v1/asesors/:id/ketersediaan", Tag: "ketersediaan", Summary: "List the asesor's availability",
+			Auth: true, Roles: admin, Query: rangeQuery, Response: []models.AsesorKetersediaan{}},
+		{Method: http.MethodPost, Path: "/api/v1/asesors/:id/ketersediaan", Tag: "ketersediaan", Summary: "Add an availability period",
+			Auth: true, Roles: admin, Request: controllers.KetersediaanRequest{}, Status: http.StatusCreated, Response: models.AsesorKetersediaan{}},
+		{Method: http.MethodDelete, Path: "/api/v1/asesors/:id/ketersediaan/:ketersediaan_id", Tag: "ketersediaan", Summary: "Delete an availability period",
+			Auth: true, Roles: admin},
+		{Method: http.MethodGet, Path: "/api/v1/jadwal/free-slots", Tag: "ketersediaan", Summary: "Find free slots of asesors qualified for a kompetensi",
+			Auth: true, Roles: admin, Response: []services.AsesorSlots{},
+			Query: append([]openapi.Param{{Name: "kompetensi_id", Required: true, Type: "integer"}}, rangeQuery...)},
+		{Method: http.MethodGet, Path: "/api/v1/asesor/me/ketersediaan", Tag: "ketersediaan", Summary: "List own availability",
+			Auth: true, Roles: asesor, Query: rangeQuery, Response: []models.AsesorKetersediaan{}},
+		{Method: http.MethodPost, Path: "/api/v1/asesor/me/ketersediaan", Tag: "ketersediaan", Summary: "Add an own availability period",
+			Auth: true, Roles: asesor, Request: controllers.KetersediaanRequest{}, Status: http.StatusCreated, Response: models.AsesorKetersediaan{}},
+		{Method: http.MethodDelete, Path: "/api/v1/asesor/me/ketersediaan/:ketersediaan_id", Tag: "ketersediaan", Summary: "Delete an own availability period",
+			Auth: true, Roles: asesor},
+		{Method: http.MethodGet, Path: "/api/v1/asesor/me/calendar", Tag: "ketersediaan", Summary: "Get the calendar feed URL",
+			Auth: true, Roles: asesor, Response: calendarURL{}},
+		{Method: http.MethodPost, Path: "/api/v1/asesor/me/calendar/rotate", Tag: "ketersediaan", Summary: "Replace the calendar feed URL",
+			Auth: true, Roles: asesor, Response: calendarURL{}},
+		{Method: http.MethodGet, Path: "/api/v1/asesor/calendar/:token", Tag: "ketersediaan", Summary: "iCalendar feed of the asesor's jadwal",
+			ContentType: "text/calendar"},
+
+		// Conflicts of interest
+		{Method: http.MethodGet, Path: "/api/v1/konflik-kepentingan/", Tag: "konflik-kepentingan", Summary: "List conflicts of interest",
+			Auth: true, Roles: admin, Response: []models.KonflikKepentingan{},
+			Query: []openapi.Param{{Name: "asesor_id", Type: "integer"}, {Name: "asesi_id", Type: "integer"}}},
+		{Method: http.MethodPost, Path: "/api/v1/konflik-kepentingan/", Tag: "konflik-kepentingan", Summary: "Record a conflict of interest",
+			Auth: true, Roles: admin, Request: controllers.RecordKonflikRequest{}, Status: http.StatusCreated, Response: models.KonflikKepentingan{}},
+		{Method: http.MethodDelete, Path: "/api/v1/konflik-kepentingan/:id", Tag: "konflik-kepentingan", Summary: "Delete a conflict of interest",
+			Auth: true, Roles: admin},
+		{Method: http.MethodGet, Path: "/api/v1/asesor/me/konflik-kepentingan", Tag: "konflik-kepentingan", Summary: "List own conflicts of interest",
+			Auth: true, Roles: asesor, Response: []models.KonflikKepentingan{}},
+		{Method: http.MethodPost, Path: "/api/v1/asesor/me/konflik-kepentingan", Tag: "konflik-kepentingan", Summary: "Declare a conflict of interest",
+			Auth: true, Roles: asesor, Request: controllers.DeclareKonflikRequest{}, Status: http.StatusCreated, Response: models.KonflikKepentingan{}},
+
+		// Kompetensi
+		{Method: http.MethodGet, Path: "/api/v1/kompetensi/", Tag: "kompetensi", Summary: "List kompetensi with their asesors",
+			Auth: true, Response: []services.KompetensiListing{}},
+		{Method: http.MethodGet, Path: "/api/v1/kompetensi/:id", Tag: "kompetensi", Summary: "Get a kompetensi with its asesors",
+			Auth: true, Response: services.KompetensiListing{}},
+		{Method: http.MethodGet, Path: "/api/v1/kompetensi/:id/units", Tag: "kompetensi", Summary: "List the units of a kompetensi",
+			Auth: true, Response: []models.UnitKompetensi{}},
+		{Method: http.MethodPost, Path: "/api/v1/kompetensi/:id/units", Tag: "kompetensi", Summary: "Add a unit with its elemen and KUK",
+			Auth: true, Roles: admin, Request: controllers.CreateUnitRequest{}, Status: http.StatusCreated, Response: models.UnitKompetensi{}},
+		{Method: http.MethodDelete, Path: "/api/v1/kompetensi/:id/units/:unit_id", Tag: "kompetensi", Summary: "Delete a unit not yet used by APL-02 or hasil asesmen",
+			Auth: true, Roles: admin},
+
+		// Jadwal
+		{Method: http.MethodPost, Path: "/api/v1/jadwal/", Tag: "jadwal", Summary: "Create a jadwal",
+			Auth: true, Roles: admin, Request: controllers.CreateJadwalRequest{}, Status: http.StatusCreated, Response: models.JadwalAsesmen{}},
+		{Method: http.MethodGet, Path: "/api/v1/jadwal/", Tag: "jadwal", Summary: "List jadwal",
+			Auth: true, Roles: admin, Response: []models.JadwalAsesmen{}},
+		{Method: http.MethodGet, Path: "/api/v1/jadwal/:id", Tag: "jadwal", Summary: "Get a jadwal",
+			Auth: true, Roles: admin, Response: models.JadwalAsesmen{}},
+		{Method: http.MethodPost, Path: "/api/v1/jadwal/:id/asesors", Tag: "jadwal", Summary: "Assign an asesor to a jadwal",
+			Auth: true, Roles: admin, Request: controllers.AssignAsesorRequest{}, Response: models.JadwalAsesmen{}},
+		{Method: http.MethodDelete, Path: "/api/v1/jadwal/:id/asesors/:asesor_id", Tag: "jadwal", Summary: "Remove an asesor from a jadwal",
+			Auth: true, Roles: admin, Response: models.JadwalAsesmen{}},
+		{Method: http.MethodGet, Path: "/api/v1/jadwal/:id/peserta", Tag: "jadwal", Summary: "List the jadwal participants",
+			Auth: true, Roles: admin, Response: []models.JadwalPeserta{}},
+		{Method: http.MethodPost, Path: "/api/v1/jadwal/:id/penugasan", Tag: "jadwal", Summary: "Propose asesor assignments",
+			Auth: true, Roles: admin, Request: controllers.ProposePenugasanRequest{}, Status: http.StatusCreated, Response: models.UsulanPenugasan{}},
+		{Method: http.MethodGet, Path: "/api/v1/jadwal/:id/penugasan", Tag: "jadwal", Summary: "List assignment proposals",
+			Auth: true, Roles: admin, Response: []models.UsulanPenugasan{}},
+		{Method: http.MethodGet, Path: "/api/v1/jadwal/:id/penugasan/:usulan_id", Tag: "jadwal", Summary: "Get an assignment proposal",
+			Auth: true, Roles: admin, Response: models.UsulanPenugasan{}},
+		{Method: http.MethodPut, Path: "/api/v1/jadwal/:id/penugasan/:usulan_id/items/:item_id", Tag: "jadwal", Summary: "Change the proposed asesor of an asesi",
+			Auth: true, Roles: admin, Request: controllers.UpdateUsulanItemRequest{}, Response: models.UsulanPenugasan{}},
+		{Method: http.MethodPost, Path: "/api/v1/jadwal/:id/penugasan/:usulan_id/accept", Tag: "jadwal", Summary: "Accept an assignment proposal",
+			Auth: true, Roles: admin, Response: models.UsulanPenugasan{}},
+
+		// TUK
+		{Method: http.MethodGet, Path: "/api/v1/tuk/", Tag: "tuk", Summary: "List TUK",
+			Auth: true, Response: []models.TUK{}},
+		{Method: http.MethodGet, Path: "/api/v1/tuk/:id", Tag: "tuk", Summary: "Get a TUK",
+			Auth: true, Response: models.TUK{}},
+		{Method: http.MethodPost, Path: "/api/v1/tuk/", Tag: "tuk", Summary: "Create a TUK",
+			Auth: true, Roles: admin, Request: controllers.TUKRequest{}, Status: http.StatusCreated, Response: models.TUK{}},
+		{Method: http.MethodPut, Path: "/api/v1/tuk/:id", Tag: "tuk", Summary: "Update a TUK",
+			Auth: true, Roles: admin, Request: controllers.TUKRequest{}, Response: models.TUK{}},
+		{Method: http.MethodDelete, Path: "/api/v1/tuk/:id", Tag: "tuk", Summary: "Delete a TUK",
+			Auth: true, Roles: admin},
+
+		// Dokumen
+		{Method: http.MethodPost, Path: "/api/v1/dokumen/", Tag: "dokumen", Summary: "Upload a dokumen",
+			Auth: true, Form: controllers.UploadDokumenRequest{}, Files: []string{"file"}, Status: http.StatusCreated, Response: models.Dokumen{}},
+		{Method: http.MethodGet, Path: "/api/v1/dokumen/:id", Tag: "dokumen", Summary: "Get dokumen metadata",
+			Auth: true, Response: models.Dokumen{}},
+		{Method: http.MethodDelete, Path: "/api/v1/dokumen/:id", Tag: "dokumen", Summary: "Delete a dokumen",
+			Auth: true},
+		{Method: http.MethodGet, Path: "/api/v1/dokumen/:id/url", Tag: "dokumen", Summary: "Create a signed download URL",
+			Auth: true, Response: controllers.DownloadURLResponse{},
+			Query: []openapi.Param{{Name: "ttl", Description: "Validity as a Go duration, 15m by default"}}},
+		{Method: http.MethodGet, Path: "/api/v1/dokumen/:id/download", Tag: "dokumen", Summary: "Download a dokumen through a signed URL",
+			ContentType: "application/octet-stream",
+			Query:       []openapi.Param{{Name: "expires", Required: true, Type: "integer"}, {Name: "signature", Required: true}}},
+
+		// APL-01
+		{Method: http.MethodGet, Path: "/api/v1/apl01/", Tag: "apl01", Summary: "List APL-01, own forms for asesi",
+			Auth: true, Roles: []string{models.RoleAsesi, models.RoleAdmin}, Response: []models.APL01{},
+			Query: []openapi.Param{{Name: "status", Description: "Filter by status, admin only"}}},
+		{Method: http.MethodGet, Path: "/api/v1/apl01/:id", Tag: "apl01", Summary: "Get an APL-01",
+			Auth: true, Roles: []string{models.RoleAsesi, models.RoleAdmin}, Response: models.APL01{}},
+		{Method: http.MethodPost, Path: "/api/v1/apl01/", Tag: "apl01", Summary: "Create an APL-01",
+			Auth: true, Roles: asesi, Request: controllers.APL01Request{}, Status: http.StatusCreated, Response: models.APL01{}},
+		{Method: http.MethodPut, Path: "/api/v1/apl01/:id", Tag: "apl01", Summary: "Update an APL-01",
+			Auth: true, Roles: asesi, Request: controllers.APL01Request{}, Response: models.APL01{}},
+		{Method: http.MethodPost, Path: "/api/v1/apl01/:id/dokumen", Tag: "apl01", Summary: "Attach a supporting dokumen",
+			Auth: true, Roles: asesi, Request: controllers.AttachDokumenRequest{}, Response: models.APL01{}},
+		{Method: http.MethodDelete, Path: "/api/v1/apl01/:id/dokumen/:dokumen_id", Tag: "apl01", Summary: "Detach a supporting dokumen",
+			Auth: true, Roles: asesi, Response: models.APL01{}},
+		{Method: http.MethodPost, Path: "/api/v1/apl01/:id/submit", Tag: "apl01", Summary: "Submit an APL-01 for review",
+			Auth: true, Roles: asesi, Response: models.APL01{}},
+		{Method: http.MethodPost, Path: "/api/v1/apl01/:id/review", Tag: "apl01", Summary: "Review a submitted APL-01",
+			Auth: true, Roles: admin, Request: controllers.ReviewAPL01Request{}, Response: models.APL01{}},
+
+		// APL-02
+		{Method: http.MethodGet, Path: "/api/v1/apl02/", Tag: "apl02", Summary: "List APL-02 visible to the user",
+			Auth: true, Response: []models.APL02{}},
+		{Method: http.MethodGet, Path: "/api/v1/apl02/:id", Tag: "apl02", Summary: "Get an APL-02",
+			Auth: true, Response: models.APL02{}},
+		{Method: http.MethodPost, Path: "/api/v1/apl02/", Tag: "apl02", Summary: "Generate the APL-02 of an approved APL-01",
+			Auth: true, Roles: asesi, Request: controllers.GenerateAPL02Request{}, Response: models.APL02{}},
+		{Method: http.MethodPut, Path: "/api/v1/apl02/:id/items/:item_id", Tag: "apl02", Summary: "Answer a self-assessment item",
+			Auth: true, Roles: asesi, Request: controllers.AnswerAPL02ItemRequest{}, Response: models.APL02{}},
+		{Method: http.MethodPost, Path: "/api/v1/apl02/:id/submit", Tag: "apl02", Summary: "Submit an APL-02",
+			Auth: true, Roles: asesi, Response: models.APL02{}},
+		{Method: http.MethodPut, Path: "/api/v1/apl02/:id/asesor", Tag: "apl02", Summary: "Assign the reviewing asesor",
+			Auth: true, Roles: admin, Request: controllers.AssignAsesorRequest{}, Response: models.APL02{}},
+		{Method: http.MethodPost, Path: "/api/v1/apl02/:id/review", Tag: "apl02", Summary: "Review an APL-02",
+			Auth: true, Roles: asesor, Request: controllers.ReviewAPL02Request{}, Response: models.APL02{}},
+
+		// Hasil asesmen
+		{Method: http.MethodGet, Path: "/api/v1/hasil-asesmen/", Tag: "hasil-asesmen", Summary: "List assessment results visible to the user",
+			Auth: true, Response: []models.HasilAsesmen{}},
+		{Method: http.MethodGet, Path: "/api/v1/hasil-asesmen/:id", Tag: "hasil-asesmen", Summary: "Get an assessment result",
+			Auth: true, Response: models.HasilAsesmen{}},
+		{Method: http.MethodPost, Path: "/api/v1/hasil-asesmen/", Tag: "hasil-asesmen", Summary: "Record an assessment result",
+			Auth: true, Roles: asesor, Request: controllers.CreateHasilAsesmenRequest{}, Status: http.StatusCreated, Response: models.HasilAsesmen{}},
+		{Method: http.MethodPut, Path: "/api/v1/hasil-asesmen/:id", Tag: "hasil-asesmen", Summary: "Update a draft assessment result",
+			Auth: true, Roles: asesor, Request: controllers.HasilAsesmenRequest{}, Response: models.HasilAsesmen{}},
+		{Method: http.MethodPost, Path: "/api/v1/hasil-asesmen/:id/sign-off", Tag: "hasil-asesmen", Summary: "Sign off an assessment result",
+			Auth: true, Roles: asesor, Response: models.HasilAsesmen{}},
+
+		// Sertifikat
+		{Method: http.MethodGet, Path: "/verify/:nomor_sertifikat", Tag: "sertifikat", Summary: "Verify a certificate by its number",
+			Response: services.SertifikatVerification{}},
+		{Method: http.MethodGet, Path: "/api/v1/sertifikat/", Tag: "sertifikat", Summary: "List certificates visible to the user",
+			Auth: true, Response: []models.Sertifikat{}},
+		{Method: http.MethodGet, Path: "/api/v1/sertifikat/:id", Tag: "sertifikat", Summary: "Get a certificate",
+			Auth: true, Response: models.Sertifikat{}},
+		{Method: http.MethodGet, Path: "/api/v1/sertifikat/:id/pdf", Tag: "sertifikat", Summary: "Download the certificate PDF",
+			Auth: true, ContentType: "application/pdf"},
+		{Method: http.MethodPost, Path: "/api/v1/sertifikat/", Tag: "sertifikat", Summary: "Issue a certificate for a kompeten result",
+			Auth: true, Roles: admin, Request: controllers.IssueSertifikatRequest{}, Status: http.StatusCreated, Response: models.Sertifikat{}},
+		{Method: http.MethodPost, Path: "/api/v1/sertifikat/:id/status", Tag: "sertifikat", Summary: "Suspend, reinstate or revoke a certificate",
+			Auth: true, Roles: admin, Request: controllers.ChangeSertifikatStatusRequest{}, Response: models.Sertifikat{}},
+		{Method: http.MethodPost, Path: "/api/v1/sertifikat/:id/renew", Tag: "sertifikat", Summary: "Renew a certificate",
+			Auth: true, Roles: admin, Request: controllers.RenewSertifikatRequest{}, Status: http.StatusCreated, Response: models.Sertifikat{}},
+
+		// Laporan
+		{Method: http.MethodGet, Path: "/api/v1/laporan/asesor", Tag: "laporan", Summary: "Assessments and pass rate per asesor",
+			Auth: true, Roles: admin, Response: []models.StatistikAsesor{},
+			Query: append([]openapi.Param{{Name: "periode", Description: "bulan (default), tahun or semua"}}, rangeQuery...)},
+		{Method: http.MethodGet, Path: "/api/v1/laporan/asesor/idle", Tag: "laporan", Summary: "Asesors without schedule or result in the period",
+			Auth: true, Roles: admin, Query: rangeQuery, Response: []models.AsesorIdle{}},
+		{Method: http.MethodGet, Path: "/api/v1/laporan/kompetensi", Tag: "laporan", Summary: "Pass rate per kompetensi",
+			Auth: true, Roles: admin, Query: rangeQuery, Response: []models.StatistikKompetensi{}},
+		{Method: http.MethodGet, Path: "/api/v1/laporan/bnsp", Tag: "laporan", Summary: "Export results in the BNSP reporting layout",
+			Auth: true, Roles: admin, ContentType: "application/octet-stream",
+			Query: append([]openapi.Param{{Name: "format", Description: "xlsx (default) or csv"}}, rangeQuery...)},
+		{Method: http.MethodGet, Path: "/api/v1/laporan/bnsp/validasi", Tag: "laporan", Summary: "List results missing mandatory BNSP fields",
+			Auth: true, Roles: admin, Query: rangeQuery, Response: bnspValidasi{}},
+	}
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAPL01NotFound           = errors.New("apl01 not found")
+	ErrAPL01NotEditable        = errors.New("apl01 can only be changed while in draft or revision")
+	ErrInvalidStatusTransition = errors.New("status transition is not allowed")
+)
+
+type APL01Service interface {
+	CreateAPL01(ctx context.Context, asesiID uint, data *models.APL01) (*models.APL01, error)
+	UpdateAPL01(ctx context.Context, id, asesiID uint, data *models.APL01) (*models.APL01, error)
+	GetAPL01ByID(ctx context.Context, id uint) (*models.APL01, error)
+	GetAPL01ByAsesi(ctx context.Context, asesiID uint) ([]models.APL01, error)
+	GetAllAPL01(ctx context.Context, status string) ([]models.APL01, error)
+	AttachDokumen(ctx context.Context, id, asesiID, dokumenID uint, keterangan string) (*models.APL01, error)
+	DetachDokumen(ctx context.Context, id, asesiID, dokumenID uint) (*models.APL01, error)
+	SubmitAPL01(ctx context.Context, id, asesiID uint) (*models.APL01, error)
+	ReviewAPL01(ctx context.Context, id, reviewerID uint, status, catatan string) (*models.APL01, error)
+}
+
+type apl01Service struct {
+	apl01Repo      repositories.APL01Repository
+	kompetensiRepo repositories.KompetensiRepository
+	dokumenRepo    repositories.DokumenRepository
+}
+
+func NewAPL01Service(
+	apl01Repo repositories.APL01Repository,
+	kompetensiRepo repositories.KompetensiRepository,
+	dokumenRepo repositories.DokumenRepository,
+) APL01Service {
+	return &apl01Service{
+		apl01Repo:      apl01Repo,
+		kompetensiRepo: kompetensiRepo,
+		dokumenRepo:    dokumenRepo,
+	}
+}
+
+func (s *apl01Service) CreateAPL01(ctx context.Context, asesiID uint, data *models.APL01) (*models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.CreateAPL01")
+	defer span.End()
+
+	_, err := s.kompetensiRepo.FindByID(ctx, data.KompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("kompetensi not found: %w", err)
+	}
+
+	apl01 := *data
+	apl01.ID = 0
+	apl01.AsesiID = asesiID
+	apl01.Status = models.StatusAPL01Draft
+
+	err = s.apl01Repo.Create(ctx, &apl01)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create apl01: %w", err)
+	}
+
+	return s.apl01Repo.FindByID(ctx, apl01.ID)
+}
+
+func (s *apl01Service) UpdateAPL01(ctx context.Context, id, asesiID uint, data *models.APL01) (*models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.UpdateAPL01")
+	defer span.End()
+
+	apl01, err := s.findOwned(ctx, id, asesiID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !apl01.Editable() {
+		return nil, ErrAPL01NotEditable
+	}
+
+	if data.KompetensiID != apl01.KompetensiID {
+		_, err := s.kompetensiRepo.FindByID(ctx, data.KompetensiID)
+		if err != nil {
+			return nil, fmt.Errorf("kompetensi not found: %w", err)
+		}
+	}
+
+	apl01.KompetensiID = data.KompetensiID
+	apl01.NamaLengkap = data.NamaLengkap
+	apl01.NIK = data.NIK
+	apl01.TempatLahir = data.TempatLahir
+	apl01.TanggalLahir = data.TanggalLahir
+	apl01.JenisKelamin = data.JenisKelamin
+	apl01.Kebangsaan = data.Kebangsaan
+	apl01.AlamatRumah = data.AlamatRumah
+	apl01.KodePos = data.KodePos
+	apl01.NoTelepon = data.NoTelepon
+	apl01.Email = data.Email
+	apl01.PendidikanTerakhir = data.PendidikanTerakhir
+	apl01.NamaInstitusi = data.NamaInstitusi
+	apl01.Jabatan = data.Jabatan
+	apl01.AlamatKantor = data.AlamatKantor
+	apl01.TeleponKantor = data.TeleponKantor
+	apl01.EmailKantor = data.EmailKantor
+	apl01.TujuanAsesmen = data.TujuanAsesmen
+
+	err = s.apl01Repo.Update(ctx, apl01)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update apl01: %w", err)
+	}
+
+	return s.apl01Repo.FindByID(ctx, id)
+}
+
+func (s *apl01Service) GetAPL01ByID(ctx context.Context, id uint) (*models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.GetAPL01ByID")
+	defer span.End()
+
+	return s.apl01Repo.FindByID(ctx, id)
+}
+
+func (s *apl01Service) GetAPL01ByAsesi(ctx context.Context, asesiID uint) ([]models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.GetAPL01ByAsesi")
+	defer span.End()
+
+	return s.apl01Repo.FindByAsesiID(ctx, asesiID)
+}
+
+func (s *apl01Service) GetAllAPL01(ctx context.Context, status string) ([]models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.GetAllAPL01")
+	defer span.End()
+
+	return s.apl01Repo.FindAll(ctx, status)
+}
+
+func (s *apl01Service) AttachDokumen(ctx context.Context, id, asesiID, dokumenID uint, keterangan string) (*models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.AttachDokumen")
+	defer span.End()
+
+	apl01, err := s.findOwned(ctx, id, asesiID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !apl01.Editable() {
+		return nil, ErrAPL01NotEditable
+	}
+
+	// Only documents the asesi uploaded themselves can be attached
+	dokumen, err := s.dokumenRepo.FindByID(ctx, dokumenID)
+	if err != nil || dokumen.UploadedBy != asesiID {
+		return nil, errors.New("dokumen not found")
+	}
+
+	for _, d := range apl01.Dokumen {
+		if d.DokumenID == dokumenID {
+			return nil, errors.New("dokumen is already attached")
+		}
+	}
+
+	err = s.apl01Repo.AddDokumen(ctx, &models.APL01Dokumen{
+		APL01ID:    apl01.ID,
+		DokumenID:  dokumen.ID,
+		Keterangan: keterangan,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach dokumen: %w", err)
+	}
+
+	return s.apl01Repo.FindByID(ctx, id)
+}
+
+func (s *apl01Service) DetachDokumen(ctx context.Context, id, asesiID, dokumenID uint) (*models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.DetachDokumen")
+	defer span.End()
+
+	apl01, err := s.findOwned(ctx, id, asesiID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !apl01.Editable() {
+		return nil, ErrAPL01NotEditable
+	}
+
+	err = s.apl01Repo.RemoveDokumen(ctx, id, dokumenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detach dokumen: %w", err)
+	}
+
+	return s.apl01Repo.FindByID(ctx, id)
+}
+
+func (s *apl01Service) SubmitAPL01(ctx context.Context, id, asesiID uint) (*models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.SubmitAPL01")
+	defer span.End()
+
+	apl01, err := s.findOwned(ctx, id, asesiID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apl01.Dokumen) == 0 {
+		return nil, errors.New("at least one supporting document must be attached before submitting")
+	}
+
+	now := time.Now()
+	apl01.DiajukanPada = &now
+
+	return s.changeStatus(ctx, apl01, models.StatusAPL01Diajukan, "", asesiID)
+}
+
+func (s *apl01Service) ReviewAPL01(ctx context.Context, id, reviewerID uint, status, catatan string) (*models.APL01, error) {
+	ctx, span := startSpan(ctx, "APL01Service.ReviewAPL01")
+	defer span.End()
+
+	apl01, err := s.apl01Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrAPL01NotFound
+	}
+
+	switch status {
+	case models.StatusAPL01Disetujui:
+	case models.StatusAPL01PerluRevisi, models.StatusAPL01Ditolak:
+		if catatan == "" {
+			return nil, errors.New("notes are required when requesting revision or rejecting")
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStatusTransition, status)
+	}
+
+	return s.changeStatus(ctx, apl01, status, catatan, reviewerID)
+}
+
+func (s *apl01Service) changeStatus(ctx context.Context, apl01 *models.APL01, status, catatan string, actorID uint) (*models.APL01, error) {
+	if !apl01.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, apl01.Status, status)
+	}
+
+	riwayat := &models.APL01Riwayat{
+		APL01ID:    apl01.ID,
+		DariStatus: apl01.Status,
+		KeStatus:   status,
+		Catatan:    catatan,
+		OlehUserID: actorID,
+	}
+
+	apl01.Status = status
+	apl01.Catatan = catatan
+
+	err := s.apl01Repo.ChangeStatus(ctx, apl01, riwayat)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Another request changed the status since it was loaded
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, riwayat.DariStatus, status)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to change apl01 status: %w", err)
+	}
+
+	return s.apl01Repo.FindByID(ctx, apl01.ID)
+}
+
+func (s *apl01Service) findOwned(ctx context.Context, id, asesiID uint) (*models.APL01, error) {
+	apl01, err := s.apl01Repo.FindByID(ctx, id)
+	if err != nil || apl01.AsesiID != asesiID {
+		return nil, ErrAPL01NotFound
+	}
+	return apl01, nil
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAPL02NotFound    = errors.New("apl02 not found")
+	ErrAPL02NotEditable = errors.New("apl02 can only be changed while in draft")
+)
+
+type APL02Service interface {
+	GenerateAPL02(ctx context.Context, apl01ID, asesiID uint) (*models.APL02, error)
+	GetAPL02ByID(ctx context.Context, id, userID uint, role string) (*models.APL02, error)
+	GetAPL02List(ctx context.Context, userID uint, role string) ([]models.APL02, error)
+	AnswerItem(ctx context.Context, id, asesiID, itemID uint, jawaban string, buktiIDs []uint) (*models.APL02, error)
+	SubmitAPL02(ctx context.Context, id, asesiID uint) (*models.APL02, error)
+	AssignAsesor(ctx context.Context, id, asesorID uint) (*models.APL02, error)
+	ReviewAPL02(ctx context.Context, id, asesorUserID uint, rekomendasi, catatan string) (*models.APL02, error)
+}
+
+type apl02Service struct {
+	apl02Repo            repositories.APL02Repository
+	apl01Repo            repositories.APL01Repository
+	unitRepo             repositories.UnitKompetensiRepository
+	asesorRepo           repositories.AsesorRepository
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository
+	konflikRepo          repositories.KonflikKepentinganRepository
+	dokumenRepo          repositories.DokumenRepository
+}
+
+func NewAPL02Service(
+	apl02Repo repositories.APL02Repository,
+	apl01Repo repositories.APL01Repository,
+	unitRepo repositories.UnitKompetensiRepository,
+	asesorRepo repositories.AsesorRepository,
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+	konflikRepo repositories.KonflikKepentinganRepository,
+	dokumenRepo repositories.DokumenRepository,
+) APL02Service {
+	return &apl02Service{
+		apl02Repo:            apl02Repo,
+		apl01Repo:            apl01Repo,
+		unitRepo:             unitRepo,
+		asesorRepo:           asesorRepo,
+		asesorKompetensiRepo: asesorKompetensiRepo,
+		konflikRepo:          konflikRepo,
+		dokumenRepo:          dokumenRepo,
+	}
+}
+
+func (s *apl02Service) GenerateAPL02(ctx context.Context, apl01ID, asesiID uint) (*models.APL02, error) {
+	ctx, span := startSpan(ctx, "APL02Service.GenerateAPL02")
+	defer span.End()
+
+	apl01, err := s.apl01Repo.FindByID(ctx, apl01ID)
+	if err != nil || apl01.AsesiID != asesiID {
+		return nil, ErrAPL01NotFound
+	}
+
+	if apl01.Status != models.StatusAPL01Disetujui {
+		return nil, errors.New("apl01 must be approved before self-assessment")
+	}
+
+	// Generating is idempotent: the asesi keeps a single APL-02 per APL-01
+	existing, err := s.apl02Repo.FindByAPL01ID(ctx, apl01ID)
+	if err == nil {
+		return existing, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	units, err := s.unitRepo.FindByKompetensiID(ctx, apl01.KompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load competency structure: %w", err)
+	}
+
+	var items []models.APL02Item
+	for _, unit := range units {
+		for _, elemen := range unit.Elemen {
+			items = append(items, models.APL02Item{ElemenID: elemen.ID})
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("kompetensi has no unit kompetensi with elemen defined")
+	}
+
+	apl02 := &models.APL02{
+		APL01ID:      apl01.ID,
+		AsesiID:      asesiID,
+		KompetensiID: apl01.KompetensiID,
+		Status:       models.StatusAPL02Draft,
+		Items:        items,
+	}
+
+	err = s.apl02Repo.Create(ctx, apl02)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create apl02: %w", err)
+	}
+
+	return s.apl02Repo.FindByID(ctx, apl02.ID)
+}
+
+func (s *apl02Service) GetAPL02ByID(ctx context.Context, id, userID uint, role string) (*models.APL02, error) {
+	ctx, span := startSpan(ctx, "APL02Service.GetAPL02ByID")
+	defer span.End()
+
+	apl02, err := s.apl02Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrAPL02NotFound
+	}
+
+	switch role {
+	case models.RoleAdmin:
+		return apl02, nil
+	case models.RoleAsesi:
+		if apl02.AsesiID == userID {
+			return apl02, nil
+		}
+	case models.RoleAsesor:
+		asesor, err := s.asesorRepo.FindByUserID(ctx, userID)
+		if err == nil && apl02.AsesorID != nil && *apl02.AsesorID == asesor.ID {
+			return apl02, nil
+		}
+	}
+
+	return nil, ErrAPL02NotFound
+}
+
+func (s *apl02Service) GetAPL02List(ctx context.Context, userID uint, role string) ([]models.APL02, error) {
+	ctx, span := startSpan(ctx, "APL02Service.GetAPL02List")
+	defer span.End()
+
+	switch role {
+	case models.RoleAdmin:
+		return s.apl02Repo.FindAll(ctx, 0, 0)
+	case models.RoleAsesi:
+		return s.apl02Repo.FindAll(ctx, userID, 0)
+	case models.RoleAsesor:
+		asesor, err := s.asesorRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("asesor not found: %w", err)
+		}
+		return s.apl02Repo.FindAll(ctx, 0, asesor.ID)
+	default:
+		return []models.APL02{}, nil
+	}
+}
+
+func (s *apl02Service) AnswerItem(ctx context.Context, id, asesiID, itemID uint, jawaban string, buktiIDs []uint) (*models.APL02, error) {
+	ctx, span := startSpan(ctx, "APL02Service.AnswerItem")
+	defer span.End()
+
+	apl02, err := s.apl02Repo.FindByID(ctx, id)
+	if err != nil || apl02.AsesiID != asesiID {
+		return nil, ErrAPL02NotFound
+	}
+
+	if apl02.Status != models.StatusAPL02Draft {
+		return nil, ErrAPL02NotEditable
+	}
+
+	if jawaban != models.JawabanKompeten && jawaban != models.JawabanBelumKompeten {
+		return nil, fmt.Errorf("invalid answer: %s", jawaban)
+	}
+
+	var item *models.APL02Item
+	for i := range apl02.Items {
+		if apl02.Items[i].ID == itemID {
+			item = &apl02.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, errors.New("apl02 item not found")
+	}
+
+	// Evidence must be documents the asesi uploaded themselves
+	bukti := make([]models.Dokumen, 0, len(buktiIDs))
+	for _, dokumenID := range buktiIDs {
+		dokumen, err := s.dokumenRepo.FindByID(ctx, dokumenID)
+		if err != nil || dokumen.UploadedBy != asesiID {
+			return nil, fmt.Errorf("dokumen %d not found", dokumenID)
+		}
+		bukti = append(bukti, *dokumen)
+	}
+
+	item.Jawaban = jawaban
+
+	err = s.apl02Repo.UpdateItem(ctx, item, bukti)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save answer: %w", err)
+	}
+
+	return s.apl02Repo.FindByID(ctx, id)
+}
+
+func (s *apl02Service) SubmitAPL02(ctx context.Context, id, asesiID uint) (*models.APL02, error) {
+	ctx, span := startSpan(ctx, "APL02Service.SubmitAPL02")
+	defer span.End()
+
+	apl02, err := s.apl02Repo.FindByID(ctx, id)
+	if err != nil || apl02.AsesiID != asesiID {
+		return nil, ErrAPL02NotFound
+	}
+
+	if apl02.Status != models.StatusAPL02Draft {
+		return nil, ErrAPL02NotEditable
+	}
+
+	for _, item := range apl02.Items {
+		if item.Jawaban == "" {
+			return nil, errors.New("every elemen must be answered before submitting")
+		}
+	}
+
+	now := time.Now()
+	apl02.Status = models.StatusAPL02Diajukan
+	apl02.DiajukanPada = &now
+
+	err = s.apl02Repo.Update(ctx, apl02)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit apl02: %w", err)
+	}
+
+	return s.apl02Repo.FindByID(ctx, id)
+}
+
+func (s *apl02Service) AssignAsesor(ctx context.Context, id, asesorID uint) (*models.APL02, error) {
+	ctx, span := startSpan(ctx, "APL02Service.AssignAsesor")
+	defer span.End()
+
+	apl02, err := s.apl02Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrAPL02NotFound
+	}
+
+	if apl02.Status == models.StatusAPL02Direview {
+		return nil, errors.New("apl02 has already been reviewed")
+	}
+
+	asesor, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	now := time.Now()
+	err = ensureAsesorEligible(ctx, s.asesorKompetensiRepo, asesor, apl02.KompetensiID, now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureNoConflict(ctx, s.konflikRepo, s.apl01Repo, asesor, apl02.AsesiID)
+	if err != nil {
+		return nil, err
+	}
+
+	apl02.AsesorID = &asesor.ID
+
+	err = s.apl02Repo.Update(ctx, apl02)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign asesor: %w", err)
+	}
+
+	return s.apl02Repo.FindByID(ctx, id)
+}
+
+func (s *apl02Service) ReviewAPL02(ctx context.Context, id, asesorUserID uint, rekomendasi, catatan string) (*models.APL02, error) {
+	ctx, span := startSpan(ctx, "APL02Service.ReviewAPL02")
+	defer span.End()
+
+	apl02, err := s.GetAPL02ByID(ctx, id, asesorUserID, models.RoleAsesor)
+	if err != nil {
+		return nil, err
+	}
+
+	if apl02.Status != models.StatusAPL02Diajukan {
+		return nil, fmt.Errorf("%w: apl02 is %s", ErrInvalidStatusTransition, apl02.Status)
+	}
+
+	if rekomendasi != models.RekomendasiLanjut && rekomendasi != models.RekomendasiTidakLanjut {
+		return nil, fmt.Errorf("invalid recommendation: %s", rekomendasi)
+	}
+
+	now := time.Now()
+	apl02.Status = models.StatusAPL02Direview
+	apl02.Rekomendasi = rekomendasi
+	apl02.CatatanAsesor = catatan
+	apl02.DireviewPada = &now
+
+	err = s.apl02Repo.Update(ctx, apl02)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review apl02: %w", err)
+	}
+
+	return s.apl02Repo.FindByID(ctx, id)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"lsp-api/internal/calendar"
+	"lsp-api/internal/config"
+	"lsp-api/internal/repositories"
+)
+
+type AsesorCalendarService interface {
+	GetCalendarURL(ctx context.Context, userID uint) (string, error)
+	RotateCalendarToken(ctx context.Context, userID uint) (string, error)
+	RenderCalendar(ctx context.Context, token string) ([]byte, error)
+}
+
+type asesorCalendarService struct {
+	userRepo   repositories.UserRepository
+	asesorRepo repositories.AsesorRepository
+	jadwalRepo repositories.JadwalRepository
+	config     *config.Config
+}
+
+func NewAsesorCalendarService(
+	userRepo repositories.UserRepository,
+	asesorRepo repositories.AsesorRepository,
+	jadwalRepo repositories.JadwalRepository,
+	config *config.Config,
+) AsesorCalendarService {
+	return &asesorCalendarService{
+		userRepo:   userRepo,
+		asesorRepo: asesorRepo,
+		jadwalRepo: jadwalRepo,
+		config:     config,
+	}
+}
+
+// GetCalendarURL returns the feed URL of the asesor, creating their secret
+// token on first use.
+func (s *asesorCalendarService) GetCalendarURL(ctx context.Context, userID uint) (string, error) {
+	ctx, span := startSpan(ctx, "AsesorCalendarService.GetCalendarURL")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("user not found: %w", err)
+	}
+
+	if user.CalendarToken != nil {
+		return s.calendarURL(*user.CalendarToken), nil
+	}
+
+	return s.RotateCalendarToken(ctx, userID)
+}
+
+// RotateCalendarToken replaces the secret token, invalidating the old feed URL.
+func (s *asesorCalendarService) RotateCalendarToken(ctx context.Context, userID uint) (string, error) {
+	ctx, span := startSpan(ctx, "AsesorCalendarService.RotateCalendarToken")
+	defer span.End()
+
+	token, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+
+	err = s.userRepo.UpdateCalendarToken(ctx, userID, token)
+	if err != nil {
+		return "", fmt.Errorf("failed to save calendar token: %w", err)
+	}
+
+	return s.calendarURL(token), nil
+}
+
+func (s *asesorCalendarService) RenderCalendar(ctx context.Context, token string) ([]byte, error) {
+	ctx, span := startSpan(ctx, "AsesorCalendarService.RenderCalendar")
+	defer span.End()
+
+	user, err := s.userRepo.FindByCalendarToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("calendar not found: %w", err)
+	}
+
+	asesor, err := s.asesorRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	jadwal, err := s.jadwalRepo.FindByAsesorID(ctx, asesor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jadwal: %w", err)
+	}
+
+	events := make([]calendar.Event, 0, len(jadwal))
+	for _, j := range jadwal {
+		events = append(events, calendar.Event{
+			UID:         fmt.Sprintf("jadwal-%d@%s", j.ID, s.calendarHost()),
+			Summary:     fmt.Sprintf("Uji Kompetensi: %s", j.Kompetensi.Nama),
+			Description: fmt.Sprintf("Skema %s (%s)", j.Kompetensi.Nama, j.Kompetensi.Kode),
+			Location:    j.Lokasi,
+			Start:       j.TanggalMulai,
+			End:         j.TanggalSelesai,
+			Updated:     j.UpdatedAt,
+		})
+	}
+
+	var buf bytes.Buffer
+	err = calendar.Write(&buf, fmt.Sprintf("Jadwal Asesmen %s", asesor.NamaLengkap), events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render calendar: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *asesorCalendarService) calendarURL(token string) string {
+	return fmt.Sprintf("%s/api/v1/asesor/calendar/%s.ics", s.config.AppBaseURL, token)
+}
+
+func (s *asesorCalendarService) calendarHost() string {
+	if u, err := url.Parse(s.config.AppBaseURL); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return "lsp-api"
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAsesorLicenseInvalid = errors.New("asesor license is not valid for the assessment period")
+	ErrAsesorNotQualified   = errors.New("asesor is not certified for the required kompetensi")
+)
+
+// ensureAsesorEligible checks that both the asesor license and their
+// certification for the kompetensi cover the whole period from start to end.
+// Every code path that gives an asesor assessment work must go through it.
+func ensureAsesorEligible(
+	ctx context.Context,
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+	asesor *models.Asesor,
+	kompetensiID uint,
+	start, end time.Time,
+) error {
+	if !asesor.LisensiBerlaku(start) || !asesor.LisensiBerlaku(end) {
+		return ErrAsesorLicenseInvalid
+	}
+
+	sertifikasi, err := asesorKompetensiRepo.FindByAsesorAndKompetensi(ctx, asesor.ID, kompetensiID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAsesorNotQualified
+		}
+		return err
+	}
+
+	if !sertifikasi.Berlaku(start) || !sertifikasi.Berlaku(end) {
+		return ErrAsesorNotQualified
+	}
+
+	return nil
+}
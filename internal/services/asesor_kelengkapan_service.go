@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"lsp-api/internal/config"
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+)
+
+// KelengkapanAsesor is the completeness breakdown of an asesor. IsComplete is
+// the stored flag, which follows Terpenuhi unless it was set manually.
+type KelengkapanAsesor struct {
+	AsesorID   uint                    `json:"asesor_id"`
+	IsComplete bool                    `json:"is_complete"`
+	Manual     bool                    `json:"manual"`
+	Terpenuhi  bool                    `json:"terpenuhi"`
+	Aturan     []string                `json:"aturan"`
+	Kekurangan []KekuranganKelengkapan `json:"kekurangan"`
+}
+
+type KekuranganKelengkapan struct {
+	Aturan     string `json:"aturan"`
+	Keterangan string `json:"keterangan"`
+}
+
+var profilAsesorFields = map[string]func(*models.Asesor) string{
+	"nama_lengkap":     func(a *models.Asesor) string { return a.NamaLengkap },
+	"no_registrasi":    func(a *models.Asesor) string { return a.NoRegistrasi },
+	"email":            func(a *models.Asesor) string { return a.Email },
+	"no_telepon":       func(a *models.Asesor) string { return a.NoTelepon },
+	"instansi":         func(a *models.Asesor) string { return a.Instansi },
+	"penerbit_lisensi": func(a *models.Asesor) string { return a.PenerbitLisensi },
+}
+
+// ValidateKelengkapanRules rejects unknown completeness rules and profile
+// fields so a typo in the configuration does not silently pass every asesor.
+func ValidateKelengkapanRules(rules, fields []string) error {
+	for _, rule := range rules {
+		switch rule {
+		case models.AturanKelengkapanProfil, models.AturanKelengkapanKompetensi,
+			models.AturanKelengkapanLisensi, models.AturanKelengkapanDokumenLisensi:
+		default:
+			return fmt.Errorf("unknown completeness rule: %s", rule)
+		}
+	}
+
+	for _, field := range fields {
+		if _, ok := profilAsesorFields[field]; !ok {
+			return fmt.Errorf("unknown asesor profile field: %s", field)
+		}
+	}
+
+	return nil
+}
+
+// kelengkapanRefreshInterval is how often Run re-evaluates every asesor, so
+// flags follow certifications and licenses that expire without a write.
+const kelengkapanRefreshInterval = time.Hour
+
+type KelengkapanAsesorService interface {
+	GetKelengkapan(ctx context.Context, asesorID uint) (*KelengkapanAsesor, error)
+	SetKelengkapan(ctx context.Context, asesorID uint, isComplete *bool) (*KelengkapanAsesor, error)
+	RefreshKelengkapan(ctx context.Context, asesor *models.Asesor) error
+	GetAsesorsByKelengkapan(ctx context.Context, isComplete bool) ([]models.Asesor, error)
+	RefreshAllKelengkapan(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+type kelengkapanAsesorService struct {
+	asesorRepo           repositories.AsesorRepository
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository
+	config               *config.Config
+}
+
+func NewKelengkapanAsesorService(
+	asesorRepo repositories.AsesorRepository,
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+	config *config.Config,
+) KelengkapanAsesorService {
+	return &kelengkapanAsesorService{
+		asesorRepo:           asesorRepo,
+		asesorKompetensiRepo: asesorKompetensiRepo,
+		config:               config,
+	}
+}
+
+func (s *kelengkapanAsesorService) GetKelengkapan(ctx context.Context, asesorID uint) (*KelengkapanAsesor, error) {
+	ctx, span := startSpan(ctx, "KelengkapanAsesorService.GetKelengkapan")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	// Reading the breakdown does not store it; RefreshKelengkapan and Run
+	// keep the stored flag up to date
+	return s.check(ctx, asesor)
+}
+
+func (s *kelengkapanAsesorService) SetKelengkapan(ctx context.Context, asesorID uint, isComplete *bool) (*KelengkapanAsesor, error) {
+	ctx, span := startSpan(ctx, "KelengkapanAsesorService.SetKelengkapan")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	kelengkapan, err := s.check(ctx, asesor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a value the manual override is cleared and the computed
+	// result applies again
+	asesor.KelengkapanManual = isComplete != nil
+	if isComplete != nil {
+		asesor.IsComplete = *isComplete
+	} else {
+		asesor.IsComplete = kelengkapan.Terpenuhi
+	}
+
+	err = s.asesorRepo.UpdateKelengkapan(ctx, asesor.ID, asesor.IsComplete, asesor.KelengkapanManual)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update kelengkapan: %w", err)
+	}
+
+	kelengkapan.IsComplete = asesor.IsComplete
+	kelengkapan.Manual = asesor.KelengkapanManual
+
+	return kelengkapan, nil
+}
+
+func (s *kelengkapanAsesorService) RefreshKelengkapan(ctx context.Context, asesor *models.Asesor) error {
+	ctx, span := startSpan(ctx, "KelengkapanAsesorService.RefreshKelengkapan")
+	defer span.End()
+
+	kelengkapan, err := s.check(ctx, asesor)
+	if err != nil {
+		return err
+	}
+
+	return s.sync(ctx, asesor, kelengkapan.Terpenuhi)
+}
+
+// GetAsesorsByKelengkapan filters on the stored flag, which Run keeps up to
+// date with expiring certifications and licenses.
+func (s *kelengkapanAsesorService) GetAsesorsByKelengkapan(ctx context.Context, isComplete bool) ([]models.Asesor, error) {
+	ctx, span := startSpan(ctx, "KelengkapanAsesorService.GetAsesorsByKelengkapan")
+	defer span.End()
+
+	return s.asesorRepo.FindByKelengkapan(ctx, isComplete)
+}
+
+// RefreshAllKelengkapan re-evaluates every asesor and stores the flags that
+// changed. Certifications and licenses expire without any write to the
+// asesor, so this has to run periodically.
+func (s *kelengkapanAsesorService) RefreshAllKelengkapan(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "KelengkapanAsesorService.RefreshAllKelengkapan")
+	defer span.End()
+
+	asesors, err := s.asesorRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sertifikasi, err := s.asesorKompetensiRepo.FindValidAt(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to load sertifikasi: %w", err)
+	}
+
+	berlaku := make(map[uint]bool)
+	for _, ak := range sertifikasi {
+		berlaku[ak.AsesorID] = true
+	}
+
+	for i := range asesors {
+		asesor := &asesors[i]
+
+		kekurangan := s.evaluate(asesor, berlaku[asesor.ID], now)
+		err = s.sync(ctx, asesor, len(kekurangan) == 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run refreshes every asesor at startup and then periodically until ctx is
+// done.
+func (s *kelengkapanAsesorService) Run(ctx context.Context) {
+	ticker := time.NewTicker(kelengkapanRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		err := s.RefreshAllKelengkapan(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to refresh asesor kelengkapan", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check evaluates the configured rules against the current state of the
+// asesor and their certifications.
+func (s *kelengkapanAsesorService) check(ctx context.Context, asesor *models.Asesor) (*KelengkapanAsesor, error) {
+	now := time.Now()
+
+	sertifikasi, err := s.asesorKompetensiRepo.FindByAsesorID(ctx, asesor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sertifikasi: %w", err)
+	}
+
+	kompetensiBerlaku := false
+	for i := range sertifikasi {
+		if sertifikasi[i].Berlaku(now) {
+			kompetensiBerlaku = true
+			break
+		}
+	}
+
+	kekurangan := s.evaluate(asesor, kompetensiBerlaku, now)
+
+	return &KelengkapanAsesor{
+		AsesorID:   asesor.ID,
+		IsComplete: asesor.IsComplete,
+		Manual:     asesor.KelengkapanManual,
+		Terpenuhi:  len(kekurangan) == 0,
+		Aturan:     s.config.AsesorCompletenessRules,
+		Kekurangan: kekurangan,
+	}, nil
+}
+
+func (s *kelengkapanAsesorService) evaluate(asesor *models.Asesor, kompetensiBerlaku bool, now time.Time) []KekuranganKelengkapan {
+	kekurangan := make([]KekuranganKelengkapan, 0)
+
+	for _, rule := range s.config.AsesorCompletenessRules {
+		switch rule {
+		case models.AturanKelengkapanProfil:
+			for _, field := range s.config.AsesorRequiredFields {
+				if value, ok := profilAsesorFields[field]; ok && value(asesor) == "" {
+					kekurangan = append(kekurangan, KekuranganKelengkapan{
+						Aturan:     rule,
+						Keterangan: fmt.Sprintf("%s is empty", field),
+					})
+				}
+			}
+		case models.AturanKelengkapanKompetensi:
+			if !kompetensiBerlaku {
+				kekurangan = append(kekurangan, KekuranganKelengkapan{
+					Aturan:     rule,
+					Keterangan: "no kompetensi certification is currently valid",
+				})
+			}
+		case models.AturanKelengkapanLisensi:
+			if !asesor.LisensiBerlaku(now) {
+				kekurangan = append(kekurangan, KekuranganKelengkapan{
+					Aturan:     rule,
+					Keterangan: "asesor license is missing, inactive or expired",
+				})
+			}
+		case models.AturanKelengkapanDokumenLisensi:
+			if asesor.DokumenLisensiID == nil {
+				kekurangan = append(kekurangan, KekuranganKelengkapan{
+					Aturan:     rule,
+					Keterangan: "license document has not been uploaded",
+				})
+			}
+		}
+	}
+
+	return kekurangan
+}
+
+// sync stores the computed result unless the flag was set manually.
+func (s *kelengkapanAsesorService) sync(ctx context.Context, asesor *models.Asesor, terpenuhi bool) error {
+	if asesor.KelengkapanManual || asesor.IsComplete == terpenuhi {
+		return nil
+	}
+
+	err := s.asesorRepo.UpdateKelengkapan(ctx, asesor.ID, terpenuhi, false)
+	if err != nil {
+		return fmt.Errorf("failed to update kelengkapan: %w", err)
+	}
+
+	asesor.IsComplete = terpenuhi
+	return nil
+}
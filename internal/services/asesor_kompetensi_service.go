@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+type AsesorKompetensiService interface {
+	GetSertifikasi(ctx context.Context, asesorID uint) ([]models.AsesorKompetensi, error)
+	SaveSertifikasi(ctx context.Context, asesorID, kompetensiID uint, nomorSertifikat string, berlakuMulai, berlakuSampai time.Time) (*models.AsesorKompetensi, error)
+	RemoveSertifikasi(ctx context.Context, asesorID, kompetensiID uint) error
+	UploadDokumenBukti(ctx context.Context, asesorID, kompetensiID uint, fileName string, content io.Reader, uploadedBy uint) (*models.AsesorKompetensi, error)
+	GetDokumenBuktiID(ctx context.Context, asesorID, kompetensiID uint) (uint, error)
+}
+
+type asesorKompetensiService struct {
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository
+	asesorRepo           repositories.AsesorRepository
+	kompetensiRepo       repositories.KompetensiRepository
+	dokumenService       DokumenService
+	kelengkapanService   KelengkapanAsesorService
+}
+
+func NewAsesorKompetensiService(
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+	asesorRepo repositories.AsesorRepository,
+	kompetensiRepo repositories.KompetensiRepository,
+	dokumenService DokumenService,
+	kelengkapanService KelengkapanAsesorService,
+) AsesorKompetensiService {
+	return &asesorKompetensiService{
+		asesorKompetensiRepo: asesorKompetensiRepo,
+		asesorRepo:           asesorRepo,
+		kompetensiRepo:       kompetensiRepo,
+		dokumenService:       dokumenService,
+		kelengkapanService:   kelengkapanService,
+	}
+}
+
+func (s *asesorKompetensiService) GetSertifikasi(ctx context.Context, asesorID uint) ([]models.AsesorKompetensi, error) {
+	ctx, span := startSpan(ctx, "AsesorKompetensiService.GetSertifikasi")
+	defer span.End()
+
+	_, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	return s.asesorKompetensiRepo.FindByAsesorID(ctx, asesorID)
+}
+
+func (s *asesorKompetensiService) SaveSertifikasi(ctx context.Context, asesorID, kompetensiID uint, nomorSertifikat string, berlakuMulai, berlakuSampai time.Time) (*models.AsesorKompetensi, error) {
+	ctx, span := startSpan(ctx, "AsesorKompetensiService.SaveSertifikasi")
+	defer span.End()
+
+	if !berlakuSampai.After(berlakuMulai) {
+		return nil, errors.New("certificate valid-until date must be after valid-from date")
+	}
+
+	asesor, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	_, err = s.kompetensiRepo.FindByID(ctx, kompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("kompetensi not found: %w", err)
+	}
+
+	// Update the existing pair or link the kompetensi if it is new for this asesor
+	asesorKompetensi, err := s.asesorKompetensiRepo.FindByAsesorAndKompetensi(ctx, asesorID, kompetensiID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		asesorKompetensi = &models.AsesorKompetensi{
+			AsesorID:     asesorID,
+			KompetensiID: kompetensiID,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	asesorKompetensi.NomorSertifikat = nomorSertifikat
+	asesorKompetensi.BerlakuMulai = &berlakuMulai
+	asesorKompetensi.BerlakuSampai = &berlakuSampai
+
+	err = s.asesorKompetensiRepo.Save(ctx, asesorKompetensi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sertifikasi: %w", err)
+	}
+
+	err = s.kelengkapanService.RefreshKelengkapan(ctx, asesor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.asesorKompetensiRepo.FindByAsesorAndKompetensi(ctx, asesorID, kompetensiID)
+}
+
+func (s *asesorKompetensiService) RemoveSertifikasi(ctx context.Context, asesorID, kompetensiID uint) error {
+	ctx, span := startSpan(ctx, "AsesorKompetensiService.RemoveSertifikasi")
+	defer span.End()
+
+	asesorKompetensi, err := s.asesorKompetensiRepo.FindByAsesorAndKompetensi(ctx, asesorID, kompetensiID)
+	if err != nil {
+		return fmt.Errorf("sertifikasi not found: %w", err)
+	}
+
+	err = s.asesorKompetensiRepo.Delete(ctx, asesorID, kompetensiID)
+	if err != nil {
+		return err
+	}
+
+	if asesorKompetensi.DokumenBuktiID != nil {
+		_ = s.dokumenService.DeleteDokumen(ctx, *asesorKompetensi.DokumenBuktiID)
+	}
+
+	asesor, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return fmt.Errorf("asesor not found: %w", err)
+	}
+
+	return s.kelengkapanService.RefreshKelengkapan(ctx, asesor)
+}
+
+func (s *asesorKompetensiService) UploadDokumenBukti(ctx context.Context, asesorID, kompetensiID uint, fileName string, content io.Reader, uploadedBy uint) (*models.AsesorKompetensi, error) {
+	ctx, span := startSpan(ctx, "AsesorKompetensiService.UploadDokumenBukti")
+	defer span.End()
+
+	asesorKompetensi, err := s.asesorKompetensiRepo.FindByAsesorAndKompetensi(ctx, asesorID, kompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("sertifikasi not found: %w", err)
+	}
+
+	dokumen, err := s.dokumenService.UploadDokumen(ctx, fileName, content, models.KategoriDokumenSertifikat, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	previousID := asesorKompetensi.DokumenBuktiID
+	asesorKompetensi.DokumenBuktiID = &dokumen.ID
+	asesorKompetensi.DokumenBukti = dokumen
+
+	err = s.asesorKompetensiRepo.Save(ctx, asesorKompetensi)
+	if err != nil {
+		_ = s.dokumenService.DeleteDokumen(ctx, dokumen.ID)
+		return nil, fmt.Errorf("failed to save sertifikasi: %w", err)
+	}
+
+	// The replaced document is no longer referenced anywhere
+	if previousID != nil {
+		_ = s.dokumenService.DeleteDokumen(ctx, *previousID)
+	}
+
+	return asesorKompetensi, nil
+}
+
+func (s *asesorKompetensiService) GetDokumenBuktiID(ctx context.Context, asesorID, kompetensiID uint) (uint, error) {
+	ctx, span := startSpan(ctx, "AsesorKompetensiService.GetDokumenBuktiID")
+	defer span.End()
+
+	asesorKompetensi, err := s.asesorKompetensiRepo.FindByAsesorAndKompetensi(ctx, asesorID, kompetensiID)
+	if err != nil {
+		return 0, fmt.Errorf("sertifikasi not found: %w", err)
+	}
+
+	if asesorKompetensi.DokumenBuktiID == nil {
+		return 0, errors.New("no evidence document uploaded")
+	}
+
+	return *asesorKompetensi.DokumenBuktiID, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	"lsp-api/internal/imaging"
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"github.com/gabriel-vasile/mimetype"
+	"gorm.io/gorm"
+)
+
+type mediaSpec struct {
+	bounds    imaging.Bounds
+	format    string
+	extension string
+	kategori  string
+	normalize func(image.Image) image.Image
+	thumbnail func(image.Image) image.Image
+}
+
+// Photos are normalized to a 3:4 pas foto, signatures keep their aspect ratio
+// and transparency so they can be overlaid on generated documents.
+var mediaSpecs = map[string]mediaSpec{
+	models.JenisMediaFoto: {
+		bounds:    imaging.Bounds{MinWidth: 300, MinHeight: 400, MaxWidth: 8000, MaxHeight: 8000},
+		format:    imaging.FormatJPEG,
+		extension: ".jpg",
+		kategori:  models.KategoriDokumenFoto,
+		normalize: func(img image.Image) image.Image { return imaging.Cover(img, 600, 800) },
+		thumbnail: func(img image.Image) image.Image { return imaging.Cover(img, 150, 200) },
+	},
+	models.JenisMediaTandaTangan: {
+		bounds:    imaging.Bounds{MinWidth: 200, MinHeight: 60, MaxWidth: 4000, MaxHeight: 4000},
+		format:    imaging.FormatPNG,
+		extension: ".png",
+		kategori:  models.KategoriDokumenTandaTangan,
+		normalize: func(img image.Image) image.Image { return imaging.Fit(img, 600, 200) },
+		thumbnail: func(img image.Image) image.Image { return imaging.Fit(img, 150, 50) },
+	},
+}
+
+type AsesorMediaService interface {
+	UploadMedia(ctx context.Context, asesorID uint, jenis string, content io.Reader, uploadedBy uint) (*models.AsesorMedia, error)
+	GetMedia(ctx context.Context, asesorID uint, jenis string) (*models.AsesorMedia, error)
+	OpenMedia(ctx context.Context, asesorID uint, jenis string, thumbnail bool) (*models.Dokumen, io.ReadCloser, error)
+}
+
+type asesorMediaService struct {
+	asesorMediaRepo repositories.AsesorMediaRepository
+	asesorRepo      repositories.AsesorRepository
+	dokumenService  DokumenService
+}
+
+func NewAsesorMediaService(
+	asesorMediaRepo repositories.AsesorMediaRepository,
+	asesorRepo repositories.AsesorRepository,
+	dokumenService DokumenService,
+) AsesorMediaService {
+	return &asesorMediaService{
+		asesorMediaRepo: asesorMediaRepo,
+		asesorRepo:      asesorRepo,
+		dokumenService:  dokumenService,
+	}
+}
+
+func (s *asesorMediaService) UploadMedia(ctx context.Context, asesorID uint, jenis string, content io.Reader, uploadedBy uint) (*models.AsesorMedia, error) {
+	ctx, span := startSpan(ctx, "AsesorMediaService.UploadMedia")
+	defer span.End()
+
+	spec, ok := mediaSpecs[jenis]
+	if !ok {
+		return nil, fmt.Errorf("unknown media type: %s", jenis)
+	}
+
+	_, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	maxSize := s.dokumenService.MaxUploadSize()
+	data, err := io.ReadAll(io.LimitReader(content, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	mtype := mimetype.Detect(data)
+	if !mimeAllowed(mtype, imageTypes) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFileType, mtype.String())
+	}
+
+	img, _, err := imaging.Decode(data, spec.bounds)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := spec.normalize(img)
+
+	var normalizedBuf, thumbnailBuf bytes.Buffer
+	if err := imaging.Encode(&normalizedBuf, normalized, spec.format); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	if err := imaging.Encode(&thumbnailBuf, spec.thumbnail(img), spec.format); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	baseName := fmt.Sprintf("asesor-%d-%s", asesorID, jenis)
+
+	dokumen, err := s.dokumenService.UploadImage(ctx, baseName+spec.extension, &normalizedBuf, spec.kategori, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, err := s.dokumenService.UploadImage(ctx, baseName+"-thumb"+spec.extension, &thumbnailBuf, spec.kategori, uploadedBy)
+	if err != nil {
+		_ = s.dokumenService.DeleteDokumen(ctx, dokumen.ID)
+		return nil, err
+	}
+
+	// Replace the existing media in place so the asesor keeps a single photo and signature
+	media, err := s.asesorMediaRepo.FindByAsesorAndJenis(ctx, asesorID, jenis)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		media = &models.AsesorMedia{AsesorID: asesorID, Jenis: jenis}
+	} else if err != nil {
+		return nil, err
+	}
+
+	previousIDs := []uint{media.DokumenID, media.ThumbnailID}
+
+	bounds := normalized.Bounds()
+	media.DokumenID = dokumen.ID
+	media.ThumbnailID = thumbnail.ID
+	media.Lebar = bounds.Dx()
+	media.Tinggi = bounds.Dy()
+
+	err = s.asesorMediaRepo.Save(ctx, media)
+	if err != nil {
+		_ = s.dokumenService.DeleteDokumen(ctx, dokumen.ID)
+		_ = s.dokumenService.DeleteDokumen(ctx, thumbnail.ID)
+		return nil, fmt.Errorf("failed to save asesor media: %w", err)
+	}
+
+	for _, id := range previousIDs {
+		if id != 0 {
+			_ = s.dokumenService.DeleteDokumen(ctx, id)
+		}
+	}
+
+	return media, nil
+}
+
+func (s *asesorMediaService) GetMedia(ctx context.Context, asesorID uint, jenis string) (*models.AsesorMedia, error) {
+	ctx, span := startSpan(ctx, "AsesorMediaService.GetMedia")
+	defer span.End()
+
+	return s.asesorMediaRepo.FindByAsesorAndJenis(ctx, asesorID, jenis)
+}
+
+// OpenMedia returns the stored image so it can be served or embedded in
+// generated documents such as assessment records and certificates.
+func (s *asesorMediaService) OpenMedia(ctx context.Context, asesorID uint, jenis string, thumbnail bool) (*models.Dokumen, io.ReadCloser, error) {
+	ctx, span := startSpan(ctx, "AsesorMediaService.OpenMedia")
+	defer span.End()
+
+	media, err := s.asesorMediaRepo.FindByAsesorAndJenis(ctx, asesorID, jenis)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if thumbnail {
+		return s.dokumenService.OpenDokumen(ctx, media.ThumbnailID)
+	}
+	return s.dokumenService.OpenDokumen(ctx, media.DokumenID)
+}
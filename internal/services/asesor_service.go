@@ -1,8 +1,13 @@
 package services
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"lsp-api/internal/models"
 	"lsp-api/internal/repositories"
@@ -11,29 +16,57 @@ import (
 )
 
 type AsesorService interface {
-	CreateAsesor(namaLengkap, noRegistrasi, email, noTelepon string, kompetensiIDs []uint) (*models.Asesor, error)
-	UpdateAsesor(id uint, namaLengkap, noRegistrasi, email, noTelepon string, kompetensiIDs []uint) (*models.Asesor, error)
-	DeleteAsesor(id uint) error
-	GetAsesorByID(id uint) (*models.Asesor, error)
-	GetAllAsesors() ([]models.Asesor, error)
-	GetAsesorByNoRegistrasi(noRegistrasi string) (*models.Asesor, error)
+	CreateAsesor(ctx context.Context, namaLengkap, noRegistrasi, email, noTelepon, instansi string, kompetensiIDs []uint) (*models.Asesor, error)
+	UpdateAsesor(ctx context.Context, id uint, namaLengkap, noRegistrasi, email, noTelepon, instansi string, kompetensiIDs []uint) (*models.Asesor, error)
+	DeleteAsesor(ctx context.Context, id uint) error
+	GetAsesorByID(ctx context.Context, id uint) (*models.Asesor, error)
+	GetAllAsesors(ctx context.Context) ([]models.Asesor, error)
+	GetAsesorByNoRegistrasi(ctx context.Context, noRegistrasi string) (*models.Asesor, error)
+	InviteAsesor(ctx context.Context, id uint) (*models.AsesorInvitation, error)
+	AcceptInvitation(ctx context.Context, token, username, password string) (*models.User, error)
+	GetAsesorByUserID(ctx context.Context, userID uint) (*models.Asesor, error)
+	UpdateOwnContact(ctx context.Context, userID uint, email, noTelepon string) (*models.Asesor, error)
+	UpdateLisensi(ctx context.Context, id uint, tanggalTerbit, tanggalKadaluarsa time.Time, penerbit, status string) (*models.Asesor, error)
+	GetAsesorsWithExpiringLisensi(ctx context.Context, days int) ([]models.Asesor, error)
+	UploadDokumenLisensi(ctx context.Context, id uint, fileName string, content io.Reader, uploadedBy uint) (*models.Asesor, error)
+	GetDokumenLisensiID(ctx context.Context, id uint) (uint, error)
 }
 
+const invitationTTL = 72 * time.Hour
+
 type asesorService struct {
-	asesorRepo     repositories.AsesorRepository
-	kompetensiRepo repositories.KompetensiRepository
+	asesorRepo         repositories.AsesorRepository
+	kompetensiRepo     repositories.KompetensiRepository
+	userRepo           repositories.UserRepository
+	invitationRepo     repositories.AsesorInvitationRepository
+	dokumenService     DokumenService
+	kelengkapanService KelengkapanAsesorService
 }
 
-func NewAsesorService(asesorRepo repositories.AsesorRepository, kompetensiRepo repositories.KompetensiRepository) AsesorService {
+func NewAsesorService(
+	asesorRepo repositories.AsesorRepository,
+	kompetensiRepo repositories.KompetensiRepository,
+	userRepo repositories.UserRepository,
+	invitationRepo repositories.AsesorInvitationRepository,
+	dokumenService DokumenService,
+	kelengkapanService KelengkapanAsesorService,
+) AsesorService {
 	return &asesorService{
-		asesorRepo:     asesorRepo,
-		kompetensiRepo: kompetensiRepo,
+		asesorRepo:         asesorRepo,
+		kompetensiRepo:     kompetensiRepo,
+		userRepo:           userRepo,
+		invitationRepo:     invitationRepo,
+		dokumenService:     dokumenService,
+		kelengkapanService: kelengkapanService,
 	}
 }
 
-func (s *asesorService) CreateAsesor(namaLengkap, noRegistrasi, email, noTelepon string, kompetensiIDs []uint) (*models.Asesor, error) {
+func (s *asesorService) CreateAsesor(ctx context.Context, namaLengkap, noRegistrasi, email, noTelepon, instansi string, kompetensiIDs []uint) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.CreateAsesor")
+	defer span.End()
+
 	// Check if asesor with the same registration number already exists
-	_, err := s.asesorRepo.FindByNoRegistrasi(noRegistrasi)
+	_, err := s.asesorRepo.FindByNoRegistrasi(ctx, noRegistrasi)
 	if err == nil {
 		return nil, errors.New("asesor with this registration number already exists")
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -41,7 +74,7 @@ func (s *asesorService) CreateAsesor(namaLengkap, noRegistrasi, email, noTelepon
 	}
 
 	// Get kompetensi by IDs
-	kompetensi, err := s.kompetensiRepo.FindByIDs(kompetensiIDs)
+	kompetensi, err := s.kompetensiRepo.FindByIDs(ctx, kompetensiIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find kompetensi: %w", err)
 	}
@@ -56,27 +89,36 @@ func (s *asesorService) CreateAsesor(namaLengkap, noRegistrasi, email, noTelepon
 		NoRegistrasi: noRegistrasi,
 		Email:        email,
 		NoTelepon:    noTelepon,
+		Instansi:     instansi,
 		Kompetensi:   kompetensi,
 	}
 
-	err = s.asesorRepo.Create(asesor)
+	err = s.asesorRepo.Create(ctx, asesor)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create asesor: %w", err)
 	}
 
+	err = s.kelengkapanService.RefreshKelengkapan(ctx, asesor)
+	if err != nil {
+		return nil, err
+	}
+
 	return asesor, nil
 }
 
-func (s *asesorService) UpdateAsesor(id uint, namaLengkap, noRegistrasi, email, noTelepon string, kompetensiIDs []uint) (*models.Asesor, error) {
+func (s *asesorService) UpdateAsesor(ctx context.Context, id uint, namaLengkap, noRegistrasi, email, noTelepon, instansi string, kompetensiIDs []uint) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.UpdateAsesor")
+	defer span.End()
+
 	// Check if asesor exists
-	asesor, err := s.asesorRepo.FindByID(id)
+	asesor, err := s.asesorRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("asesor not found: %w", err)
 	}
 
 	// Check if registration number is already used by another asesor
 	if asesor.NoRegistrasi != noRegistrasi {
-		existingAsesor, err := s.asesorRepo.FindByNoRegistrasi(noRegistrasi)
+		existingAsesor, err := s.asesorRepo.FindByNoRegistrasi(ctx, noRegistrasi)
 		if err == nil && existingAsesor.ID != id {
 			return nil, errors.New("registration number already used by another asesor")
 		} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -85,7 +127,7 @@ func (s *asesorService) UpdateAsesor(id uint, namaLengkap, noRegistrasi, email,
 	}
 
 	// Get kompetensi by IDs
-	kompetensi, err := s.kompetensiRepo.FindByIDs(kompetensiIDs)
+	kompetensi, err := s.kompetensiRepo.FindByIDs(ctx, kompetensiIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find kompetensi: %w", err)
 	}
@@ -99,34 +141,294 @@ func (s *asesorService) UpdateAsesor(id uint, namaLengkap, noRegistrasi, email,
 	asesor.NoRegistrasi = noRegistrasi
 	asesor.Email = email
 	asesor.NoTelepon = noTelepon
+	asesor.Instansi = instansi
 	asesor.Kompetensi = kompetensi
 
-	err = s.asesorRepo.Update(asesor)
+	err = s.asesorRepo.Update(ctx, asesor)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update asesor: %w", err)
 	}
 
+	err = s.kelengkapanService.RefreshKelengkapan(ctx, asesor)
+	if err != nil {
+		return nil, err
+	}
+
 	return asesor, nil
 }
 
-func (s *asesorService) DeleteAsesor(id uint) error {
+func (s *asesorService) DeleteAsesor(ctx context.Context, id uint) error {
+	ctx, span := startSpan(ctx, "AsesorService.DeleteAsesor")
+	defer span.End()
+
 	// Check if asesor exists
-	_, err := s.asesorRepo.FindByID(id)
+	_, err := s.asesorRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("asesor not found: %w", err)
 	}
 
-	return s.asesorRepo.Delete(id)
+	return s.asesorRepo.Delete(ctx, id)
+}
+
+func (s *asesorService) GetAsesorByID(ctx context.Context, id uint) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.GetAsesorByID")
+	defer span.End()
+
+	return s.asesorRepo.FindByID(ctx, id)
+}
+
+func (s *asesorService) GetAllAsesors(ctx context.Context) ([]models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.GetAllAsesors")
+	defer span.End()
+
+	return s.asesorRepo.FindAll(ctx)
+}
+
+func (s *asesorService) GetAsesorByNoRegistrasi(ctx context.Context, noRegistrasi string) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.GetAsesorByNoRegistrasi")
+	defer span.End()
+
+	return s.asesorRepo.FindByNoRegistrasi(ctx, noRegistrasi)
+}
+
+func (s *asesorService) InviteAsesor(ctx context.Context, id uint) (*models.AsesorInvitation, error) {
+	ctx, span := startSpan(ctx, "AsesorService.InviteAsesor")
+	defer span.End()
+
+	// Check if asesor exists and is not linked yet
+	asesor, err := s.asesorRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	if asesor.UserID != nil {
+		return nil, errors.New("asesor is already linked to a user account")
+	}
+
+	// The account will be created with the asesor's email, so it must be free
+	_, err = s.userRepo.FindByEmail(ctx, asesor.Email)
+	if err == nil {
+		return nil, errors.New("user with this email already exists")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	invitation := &models.AsesorInvitation{
+		AsesorID:  asesor.ID,
+		Email:     asesor.Email,
+		Token:     token,
+		ExpiresAt: time.Now().Add(invitationTTL),
+	}
+
+	err = s.invitationRepo.Create(ctx, invitation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (s *asesorService) AcceptInvitation(ctx context.Context, token, username, password string) (*models.User, error) {
+	ctx, span := startSpan(ctx, "AsesorService.AcceptInvitation")
+	defer span.End()
+
+	invitation, err := s.invitationRepo.FindByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid invitation token")
+		}
+		return nil, err
+	}
+
+	if invitation.AcceptedAt != nil {
+		return nil, errors.New("invitation has already been used")
+	}
+
+	if time.Now().After(invitation.ExpiresAt) {
+		return nil, errors.New("invitation has expired")
+	}
+
+	asesor, err := s.asesorRepo.FindByID(ctx, invitation.AsesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	user := &models.User{
+		Username: username,
+		FullName: asesor.NamaLengkap,
+		Email:    invitation.Email,
+		Password: password,
+		Role:     models.RoleAsesor,
+	}
+
+	err = s.invitationRepo.Accept(ctx, invitation, user)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("asesor is already linked to a user account")
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *asesorService) GetAsesorByUserID(ctx context.Context, userID uint) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.GetAsesorByUserID")
+	defer span.End()
+
+	return s.asesorRepo.FindByUserID(ctx, userID)
+}
+
+func (s *asesorService) UpdateOwnContact(ctx context.Context, userID uint, email, noTelepon string) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.UpdateOwnContact")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	// Check if email is already used by another asesor
+	if asesor.Email != email {
+		existingAsesor, err := s.asesorRepo.FindByEmail(ctx, email)
+		if err == nil && existingAsesor.ID != asesor.ID {
+			return nil, errors.New("email already used by another asesor")
+		} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	asesor.Email = email
+	asesor.NoTelepon = noTelepon
+
+	err = s.asesorRepo.Update(ctx, asesor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update asesor: %w", err)
+	}
+
+	err = s.kelengkapanService.RefreshKelengkapan(ctx, asesor)
+	if err != nil {
+		return nil, err
+	}
+
+	return asesor, nil
 }
 
-func (s *asesorService) GetAsesorByID(id uint) (*models.Asesor, error) {
-	return s.asesorRepo.FindByID(id)
+func (s *asesorService) UpdateLisensi(ctx context.Context, id uint, tanggalTerbit, tanggalKadaluarsa time.Time, penerbit, status string) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.UpdateLisensi")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	if !tanggalKadaluarsa.After(tanggalTerbit) {
+		return nil, errors.New("license expiry date must be after issue date")
+	}
+
+	switch status {
+	case models.StatusLisensiAktif, models.StatusLisensiDibekukan, models.StatusLisensiDicabut:
+	default:
+		return nil, fmt.Errorf("invalid license status: %s", status)
+	}
+
+	asesor.TanggalTerbitLisensi = &tanggalTerbit
+	asesor.TanggalKadaluarsaLisensi = &tanggalKadaluarsa
+	asesor.PenerbitLisensi = penerbit
+	asesor.StatusLisensi = status
+
+	err = s.asesorRepo.Update(ctx, asesor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update asesor license: %w", err)
+	}
+
+	err = s.kelengkapanService.RefreshKelengkapan(ctx, asesor)
+	if err != nil {
+		return nil, err
+	}
+
+	return asesor, nil
 }
 
-func (s *asesorService) GetAllAsesors() ([]models.Asesor, error) {
-	return s.asesorRepo.FindAll()
+func (s *asesorService) GetAsesorsWithExpiringLisensi(ctx context.Context, days int) ([]models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.GetAsesorsWithExpiringLisensi")
+	defer span.End()
+
+	if days <= 0 {
+		return nil, errors.New("days must be greater than zero")
+	}
+
+	// Licenses that already lapsed stay on the list, oldest first, until
+	// they are renewed
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	return s.asesorRepo.FindLisensiExpiringBy(ctx, today.AddDate(0, 0, days))
+}
+
+func (s *asesorService) UploadDokumenLisensi(ctx context.Context, id uint, fileName string, content io.Reader, uploadedBy uint) (*models.Asesor, error) {
+	ctx, span := startSpan(ctx, "AsesorService.UploadDokumenLisensi")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	dokumen, err := s.dokumenService.UploadDokumen(ctx, fileName, content, models.KategoriDokumenSertifikat, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	previousID := asesor.DokumenLisensiID
+	asesor.DokumenLisensiID = &dokumen.ID
+	asesor.DokumenLisensi = dokumen
+
+	err = s.asesorRepo.Update(ctx, asesor)
+	if err != nil {
+		_ = s.dokumenService.DeleteDokumen(ctx, dokumen.ID)
+		return nil, fmt.Errorf("failed to update asesor license: %w", err)
+	}
+
+	// The replaced document is no longer referenced anywhere
+	if previousID != nil {
+		_ = s.dokumenService.DeleteDokumen(ctx, *previousID)
+	}
+
+	err = s.kelengkapanService.RefreshKelengkapan(ctx, asesor)
+	if err != nil {
+		return nil, err
+	}
+
+	return asesor, nil
+}
+
+func (s *asesorService) GetDokumenLisensiID(ctx context.Context, id uint) (uint, error) {
+	ctx, span := startSpan(ctx, "AsesorService.GetDokumenLisensiID")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByID(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	if asesor.DokumenLisensiID == nil {
+		return 0, errors.New("no license document uploaded")
+	}
+
+	return *asesor.DokumenLisensiID, nil
 }
 
-func (s *asesorService) GetAsesorByNoRegistrasi(noRegistrasi string) (*models.Asesor, error) {
-	return s.asesorRepo.FindByNoRegistrasi(noRegistrasi)
-}
\ No newline at end of file
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -1,11 +1,14 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"lsp-api/internal/config"
+	"lsp-api/internal/jwtkeys"
+	"lsp-api/internal/metrics"
 	"lsp-api/internal/models"
 	"lsp-api/internal/repositories"
 
@@ -13,27 +16,41 @@ import (
 	"gorm.io/gorm"
 )
 
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// tokenLeeway tolerates clock skew between the API and services verifying
+// its tokens.
+const tokenLeeway = 30 * time.Second
+
 type AuthService interface {
-	Register(username, fullName, email, password string) error
-	Login(email, password string) (string, error)
-	ValidateToken(tokenString string) (*jwt.Token, error)
+	Register(ctx context.Context, username, fullName, email, password, role string) error
+	Login(ctx context.Context, email, password string) (string, error)
+	ValidateToken(ctx context.Context, tokenString string) (*jwt.Token, error)
+	JWKS(ctx context.Context) jwtkeys.JSONWebKeySet
 }
 
 type authService struct {
 	userRepo repositories.UserRepository
 	config   *config.Config
+	metrics  *metrics.Metrics
+	keys     *jwtkeys.KeySet
 }
 
-func NewAuthService(userRepo repositories.UserRepository, config *config.Config) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, config *config.Config, metrics *metrics.Metrics, keys *jwtkeys.KeySet) AuthService {
 	return &authService{
 		userRepo: userRepo,
 		config:   config,
+		metrics:  metrics,
+		keys:     keys,
 	}
 }
 
-func (s *authService) Register(username, fullName, email, password string) error {
+func (s *authService) Register(ctx context.Context, username, fullName, email, password, role string) error {
+	ctx, span := startSpan(ctx, "AuthService.Register")
+	defer span.End()
+
 	// Check if user already exists
-	_, err := s.userRepo.FindByEmail(email)
+	_, err := s.userRepo.FindByEmail(ctx, email)
 	if err == nil {
 		return errors.New("user with this email already exists")
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -46,41 +63,63 @@ func (s *authService) Register(username, fullName, email, password string) error
 		FullName: fullName,
 		Email:    email,
 		Password: password,
+		Role:     role,
+	}
+
+	return s.userRepo.Create(ctx, user)
+}
+
+func (s *authService) Login(ctx context.Context, email, password string) (string, error) {
+	ctx, span := startSpan(ctx, "AuthService.Login")
+	defer span.End()
+
+	token, err := s.login(ctx, email, password)
+
+	switch {
+	case err == nil:
+		s.metrics.ObserveLogin(metrics.LoginSuccess)
+	case errors.Is(err, ErrInvalidCredentials):
+		s.metrics.ObserveLogin(metrics.LoginInvalidCredentials)
+	default:
+		s.metrics.ObserveLogin(metrics.LoginError)
 	}
 
-	return s.userRepo.Create(user)
+	return token, err
 }
 
-func (s *authService) Login(email, password string) (string, error) {
+func (s *authService) login(ctx context.Context, email, password string) (string, error) {
 	// Find user by email
-	user, err := s.userRepo.FindByEmail(email)
+	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", errors.New("invalid email or password")
+			return "", ErrInvalidCredentials
 		}
 		return "", err
 	}
 
 	// Verify password
 	if !user.ComparePassword(password) {
-		return "", errors.New("invalid email or password")
-	}
-
-	// Generate JWT token
-	expiry, err := time.ParseDuration(s.config.JWTExpiry)
-	if err != nil {
-		return "", fmt.Errorf("invalid JWT expiry time: %w", err)
+		return "", ErrInvalidCredentials
 	}
 
+	// Generate JWT token, naming the key so verifiers can pick its public key
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id":  user.ID,
 		"email":    user.Email,
 		"username": user.Username,
-		"exp":      time.Now().Add(expiry).Unix(),
+		"role":     user.Role,
+		"iss":      s.config.JWTIssuer,
+		"aud":      s.config.JWTAudience,
+		"iat":      now.Unix(),
+		"nbf":      now.Unix(),
+		"exp":      now.Add(s.config.JWTExpiry).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.config.JWTSecret))
+	key := s.keys.SigningKey()
+	token := jwt.NewWithClaims(key.Method(), claims)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.PrivateKey())
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -88,13 +127,50 @@ func (s *authService) Login(email, password string) (string, error) {
 	return tokenString, nil
 }
 
-func (s *authService) ValidateToken(tokenString string) (*jwt.Token, error) {
-	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
+func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*jwt.Token, error) {
+	ctx, span := startSpan(ctx, "AuthService.ValidateToken")
+	defer span.End()
+
+	token, err := jwt.Parse(tokenString, s.verificationKey,
+		jwt.WithValidMethods([]string{jwtkeys.AlgorithmRS256, jwtkeys.AlgorithmEdDSA}),
+		jwt.WithIssuer(s.config.JWTIssuer),
+		jwt.WithAudience(s.config.JWTAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(tokenLeeway),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The parser only checks nbf when present, every token we issue has one
+	notBefore, err := token.Claims.GetNotBefore()
+	if err != nil || notBefore == nil {
+		return nil, fmt.Errorf("%w: nbf", jwt.ErrTokenRequiredClaimMissing)
+	}
+
+	return token, nil
+}
+
+func (s *authService) JWKS(ctx context.Context) jwtkeys.JSONWebKeySet {
+	ctx, span := startSpan(ctx, "AuthService.JWKS")
+	defer span.End()
+
+	return s.keys.JWKS()
+}
+
+// verificationKey returns the public key named by the kid header of a token.
+func (s *authService) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, err := s.keys.VerificationKey(kid)
+	if err != nil {
+		return nil, err
+	}
 
-		return []byte(s.config.JWTSecret), nil
-	})
-}
\ No newline at end of file
+	// Validate signing method against the key, not just the header
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.PublicKey(), nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lsp-api/internal/config"
+	"lsp-api/internal/jwtkeys"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func loadKeys(t *testing.T, algorithm string) *jwtkeys.KeySet {
+	t.Helper()
+
+	keys, err := jwtkeys.Load(jwtkeys.Options{Dir: t.TempDir(), Algorithm: algorithm, TokenLifetime: time.Hour})
+	if err != nil {
+		t.Fatalf("loading %s keys: %v", algorithm, err)
+	}
+	return keys
+}
+
+func signToken(t *testing.T, key *jwtkeys.Key, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(key.Method(), claims)
+	token.Header["kid"] = kid
+	tokenString, err := token.SignedString(key.PrivateKey())
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return tokenString
+}
+
+func TestValidateToken(t *testing.T) {
+	cfg := &config.Config{JWTIssuer: "https://lsp.example.id", JWTAudience: "lsp-api"}
+	keys := loadKeys(t, jwtkeys.AlgorithmEdDSA)
+	service := NewAuthService(nil, cfg, nil, keys)
+
+	key := keys.SigningKey()
+	otherKey := loadKeys(t, jwtkeys.AlgorithmRS256).SigningKey()
+
+	now := time.Now()
+	claims := func(change func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"user_id": 1,
+			"role":    "admin",
+			"iss":     cfg.JWTIssuer,
+			"aud":     cfg.JWTAudience,
+			"iat":     now.Unix(),
+			"nbf":     now.Unix(),
+			"exp":     now.Add(time.Hour).Unix(),
+		}
+		if change != nil {
+			change(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"valid", signToken(t, key, key.ID, claims(nil)), true},
+		{"within leeway", signToken(t, key, key.ID, claims(func(c jwt.MapClaims) {
+			c["nbf"] = now.Add(10 * time.Second).Unix()
+		})), true},
+		{"wrong issuer", signToken(t, key, key.ID, claims(func(c jwt.MapClaims) {
+			c["iss"] = "https://evil.example.id"
+		})), false},
+		{"wrong audience", signToken(t, key, key.ID, claims(func(c jwt.MapClaims) {
+			c["aud"] = "other-service"
+		})), false},
+		{"missing nbf", signToken(t, key, key.ID, claims(func(c jwt.MapClaims) {
+			delete(c, "nbf")
+		})), false},
+		{"not yet valid", signToken(t, key, key.ID, claims(func(c jwt.MapClaims) {
+			c["nbf"] = now.Add(time.Hour).Unix()
+		})), false},
+		{"missing exp", signToken(t, key, key.ID, claims(func(c jwt.MapClaims) {
+			delete(c, "exp")
+		})), false},
+		{"expired", signToken(t, key, key.ID, claims(func(c jwt.MapClaims) {
+			c["exp"] = now.Add(-time.Hour).Unix()
+		})), false},
+		{"unknown kid", signToken(t, otherKey, otherKey.ID, claims(nil)), false},
+		{"missing kid", signToken(t, key, "", claims(nil)), false},
+		{"alg does not match key", signToken(t, otherKey, key.ID, claims(nil)), false},
+		{"unsigned", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodNone, claims(nil))
+			token.Header["kid"] = key.ID
+			tokenString, _ := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+			return tokenString
+		}(), false},
+	}
+
+	for _, tt := range tests {
+		_, err := service.ValidateToken(context.Background(), tt.token)
+		if valid := err == nil; valid != tt.valid {
+			t.Errorf("%s: valid = %v, want %v (err %v)", tt.name, valid, tt.valid, err)
+		}
+	}
+}
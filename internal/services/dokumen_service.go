@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"lsp-api/internal/config"
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+	"lsp-api/internal/storage"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+var (
+	ErrFileTooLarge        = errors.New("file exceeds the maximum upload size")
+	ErrUnsupportedFileType = errors.New("file type is not allowed")
+	ErrInvalidSignature    = errors.New("invalid or expired download signature")
+	ErrDokumenInUse        = errors.New("dokumen is still referenced and cannot be deleted")
+)
+
+const maxDownloadURLTTL = 24 * time.Hour
+
+var imageTypes = []string{"image/jpeg", "image/png"}
+
+type DokumenService interface {
+	UploadDokumen(ctx context.Context, fileName string, content io.Reader, kategori string, uploadedBy uint) (*models.Dokumen, error)
+	UploadImage(ctx context.Context, fileName string, content io.Reader, kategori string, uploadedBy uint) (*models.Dokumen, error)
+	GetDokumen(ctx context.Context, id uint) (*models.Dokumen, error)
+	OpenDokumen(ctx context.Context, id uint) (*models.Dokumen, io.ReadCloser, error)
+	DeleteDokumen(ctx context.Context, id uint) error
+	CreateDownloadURL(ctx context.Context, id uint, ttl time.Duration) (string, time.Time, error)
+	VerifyDownloadSignature(ctx context.Context, id uint, expires int64, signature string) error
+	MaxUploadSize() int64
+}
+
+type dokumenService struct {
+	dokumenRepo repositories.DokumenRepository
+	storage     storage.Storage
+	config      *config.Config
+}
+
+func NewDokumenService(dokumenRepo repositories.DokumenRepository, storage storage.Storage, config *config.Config) DokumenService {
+	return &dokumenService{
+		dokumenRepo: dokumenRepo,
+		storage:     storage,
+		config:      config,
+	}
+}
+
+func (s *dokumenService) MaxUploadSize() int64 {
+	return s.config.MaxUploadSizeMB << 20
+}
+
+func (s *dokumenService) UploadDokumen(ctx context.Context, fileName string, content io.Reader, kategori string, uploadedBy uint) (*models.Dokumen, error) {
+	ctx, span := startSpan(ctx, "DokumenService.UploadDokumen")
+	defer span.End()
+
+	return s.store(ctx, fileName, content, kategori, uploadedBy, s.config.AllowedUploadTypes)
+}
+
+func (s *dokumenService) UploadImage(ctx context.Context, fileName string, content io.Reader, kategori string, uploadedBy uint) (*models.Dokumen, error) {
+	ctx, span := startSpan(ctx, "DokumenService.UploadImage")
+	defer span.End()
+
+	return s.store(ctx, fileName, content, kategori, uploadedBy, imageTypes)
+}
+
+// store spools the upload to a temporary file so its size, type and checksum
+// are known before anything reaches the storage backend.
+func (s *dokumenService) store(ctx context.Context, fileName string, content io.Reader, kategori string, uploadedBy uint, allowedTypes []string) (*models.Dokumen, error) {
+	tmp, err := os.CreateTemp("", "lsp-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer upload: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	maxSize := s.MaxUploadSize()
+
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(content, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if size > maxSize {
+		return nil, ErrFileTooLarge
+	}
+	if size == 0 {
+		return nil, errors.New("uploaded file is empty")
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	mtype, err := mimetype.DetectReader(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect file type: %w", err)
+	}
+	if !mimeAllowed(mtype, allowedTypes) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFileType, mtype.String())
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s/%s/%s%s", kategori, time.Now().Format("2006/01"), token, mtype.Extension())
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, key, tmp, size, mtype.String()); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	dokumen := &models.Dokumen{
+		StorageKey:  key,
+		NamaFile:    fileName,
+		ContentType: mtype.String(),
+		Ukuran:      size,
+		Checksum:    hex.EncodeToString(hasher.Sum(nil)),
+		Kategori:    kategori,
+		UploadedBy:  uploadedBy,
+	}
+
+	err = s.dokumenRepo.Create(ctx, dokumen)
+	if err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return nil, fmt.Errorf("failed to save dokumen: %w", err)
+	}
+
+	return dokumen, nil
+}
+
+func (s *dokumenService) GetDokumen(ctx context.Context, id uint) (*models.Dokumen, error) {
+	ctx, span := startSpan(ctx, "DokumenService.GetDokumen")
+	defer span.End()
+
+	return s.dokumenRepo.FindByID(ctx, id)
+}
+
+func (s *dokumenService) OpenDokumen(ctx context.Context, id uint) (*models.Dokumen, io.ReadCloser, error) {
+	ctx, span := startSpan(ctx, "DokumenService.OpenDokumen")
+	defer span.End()
+
+	dokumen, err := s.dokumenRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.storage.Get(ctx, dokumen.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return dokumen, content, nil
+}
+
+func (s *dokumenService) DeleteDokumen(ctx context.Context, id uint) error {
+	ctx, span := startSpan(ctx, "DokumenService.DeleteDokumen")
+	defer span.End()
+
+	dokumen, err := s.dokumenRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("dokumen not found: %w", err)
+	}
+
+	// Submitted forms and reviewed evidence must keep their files
+	referenced, err := s.dokumenRepo.IsReferenced(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check dokumen usage: %w", err)
+	}
+	if referenced {
+		return ErrDokumenInUse
+	}
+
+	err = s.dokumenRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Delete(ctx, dokumen.StorageKey)
+}
+
+func (s *dokumenService) CreateDownloadURL(ctx context.Context, id uint, ttl time.Duration) (string, time.Time, error) {
+	ctx, span := startSpan(ctx, "DokumenService.CreateDownloadURL")
+	defer span.End()
+
+	if ttl <= 0 || ttl > maxDownloadURLTTL {
+		return "", time.Time{}, fmt.Errorf("download URL lifetime must be between 1s and %s", maxDownloadURLTTL)
+	}
+
+	dokumen, err := s.dokumenRepo.FindByID(ctx, id)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("dokumen not found: %w", err)
+	}
+
+	expiresAt := time.Now().Add(ttl)
+
+	// Let the backend serve the file directly when it supports presigning
+	if presigner, ok := s.storage.(storage.Presigner); ok {
+		url, err := presigner.PresignedURL(ctx, dokumen.StorageKey, ttl, dokumen.NamaFile)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to presign download URL: %w", err)
+		}
+		return url, expiresAt, nil
+	}
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	url := fmt.Sprintf("%s/api/v1/dokumen/%d/download?expires=%s&signature=%s",
+		s.config.AppBaseURL, dokumen.ID, expires, s.sign(dokumen.ID, expires))
+
+	return url, expiresAt, nil
+}
+
+func (s *dokumenService) VerifyDownloadSignature(ctx context.Context, id uint, expires int64, signature string) error {
+	ctx, span := startSpan(ctx, "DokumenService.VerifyDownloadSignature")
+	defer span.End()
+
+	if time.Now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+
+	expected := s.sign(id, strconv.FormatInt(expires, 10))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (s *dokumenService) sign(id uint, expires string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.FileSigningKey))
+	fmt.Fprintf(mac, "%d:%s", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func mimeAllowed(mtype *mimetype.MIME, allowedTypes []string) bool {
+	for _, allowed := range allowedTypes {
+		if mtype.Is(allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"lsp-api/internal/config"
+	"lsp-api/internal/models"
+	"lsp-api/internal/storage"
+
+	"gorm.io/gorm"
+)
+
+// memoryDokumenRepository keeps dokumen in a map in place of the database.
+type memoryDokumenRepository struct {
+	dokumen    map[uint]*models.Dokumen
+	referenced map[uint]bool
+}
+
+func (r *memoryDokumenRepository) Create(ctx context.Context, dokumen *models.Dokumen) error {
+	dokumen.ID = uint(len(r.dokumen) + 1)
+	r.dokumen[dokumen.ID] = dokumen
+	return nil
+}
+
+func (r *memoryDokumenRepository) Delete(ctx context.Context, id uint) error {
+	delete(r.dokumen, id)
+	return nil
+}
+
+func (r *memoryDokumenRepository) IsReferenced(ctx context.Context, id uint) (bool, error) {
+	return r.referenced[id], nil
+}
+
+func (r *memoryDokumenRepository) FindByID(ctx context.Context, id uint) (*models.Dokumen, error) {
+	dokumen, ok := r.dokumen[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return dokumen, nil
+}
+
+var (
+	pdfContent = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\ntrailer\n<<>>\n%%EOF\n")
+	pngContent = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+)
+
+func newTestDokumenService(t *testing.T) (DokumenService, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	local, err := storage.NewLocalStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		AppBaseURL:         "https://lsp.example.id",
+		MaxUploadSizeMB:    1,
+		AllowedUploadTypes: []string{"application/pdf", "image/jpeg", "image/png"},
+		FileSigningKey:     "test-signing-key",
+	}
+	repo := &memoryDokumenRepository{dokumen: map[uint]*models.Dokumen{}, referenced: map[uint]bool{}}
+	return NewDokumenService(repo, local, cfg), dir
+}
+
+// storedFiles counts the files written to the storage directory.
+func storedFiles(t *testing.T, dir string) int {
+	t.Helper()
+
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			count++
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestUploadValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   bool
+		content []byte
+		wantErr error
+		wantCT  string
+	}{
+		{name: "pdf", content: pdfContent, wantCT: "application/pdf"},
+		{name: "png", content: pngContent, wantCT: "image/png"},
+		{name: "image upload png", image: true, content: pngContent, wantCT: "image/png"},
+		{name: "text renamed to pdf", content: []byte("just some text"), wantErr: ErrUnsupportedFileType},
+		{name: "html", content: []byte("<html><script>alert(1)</script></html>"), wantErr: ErrUnsupportedFileType},
+		{name: "image upload pdf", image: true, content: pdfContent, wantErr: ErrUnsupportedFileType},
+		{name: "too large", content: append(append([]byte{}, pdfContent...), make([]byte, 1<<20)...), wantErr: ErrFileTooLarge},
+		{name: "exactly the limit", content: append(append([]byte{}, pdfContent...), make([]byte, 1<<20-len(pdfContent))...), wantCT: "application/pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, dir := newTestDokumenService(t)
+			upload := service.UploadDokumen
+			if tt.image {
+				upload = service.UploadImage
+			}
+
+			dokumen, err := upload(context.Background(), "berkas.pdf", bytes.NewReader(tt.content), "apl01", 1)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				if n := storedFiles(t, dir); n != 0 {
+					t.Errorf("rejected upload left %d files in storage", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("upload: %v", err)
+			}
+
+			if dokumen.ContentType != tt.wantCT || dokumen.Ukuran != int64(len(tt.content)) {
+				t.Errorf("stored as %s of %d bytes, want %s of %d", dokumen.ContentType, dokumen.Ukuran, tt.wantCT, len(tt.content))
+			}
+			if n := storedFiles(t, dir); n != 1 {
+				t.Errorf("upload stored %d files, want 1", n)
+			}
+		})
+	}
+}
+
+func TestUploadRejectsEmptyFile(t *testing.T) {
+	service, _ := newTestDokumenService(t)
+
+	_, err := service.UploadDokumen(context.Background(), "kosong.pdf", bytes.NewReader(nil), "apl01", 1)
+	if err == nil {
+		t.Fatal("empty upload succeeded")
+	}
+}
+
+func TestDownloadSignature(t *testing.T) {
+	service, _ := newTestDokumenService(t)
+	ctx := context.Background()
+
+	dokumen, err := service.UploadDokumen(ctx, "berkas.pdf", bytes.NewReader(pdfContent), "apl01", 1)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	link, expiresAt, err := service.CreateDownloadURL(ctx, dokumen.ID, time.Hour)
+	if err != nil {
+		t.Fatalf("CreateDownloadURL: %v", err)
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", link, err)
+	}
+	if want := "/api/v1/dokumen/" + strconv.Itoa(int(dokumen.ID)) + "/download"; parsed.Path != want {
+		t.Errorf("download path %s, want %s", parsed.Path, want)
+	}
+
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	if err != nil || expires != expiresAt.Unix() {
+		t.Fatalf("expires = %q, want %d", parsed.Query().Get("expires"), expiresAt.Unix())
+	}
+	signature := parsed.Query().Get("signature")
+
+	tampered := []byte(signature)
+	tampered[0] ^= 1
+
+	otherService, _ := newTestDokumenService(t)
+	otherService.(*dokumenService).config.FileSigningKey = "another-key"
+
+	past := time.Now().Add(-time.Minute).Unix()
+	pastSignature := service.(*dokumenService).sign(dokumen.ID, strconv.FormatInt(past, 10))
+
+	tests := []struct {
+		name      string
+		service   DokumenService
+		id        uint
+		expires   int64
+		signature string
+		valid     bool
+	}{
+		{"valid", service, dokumen.ID, expires, signature, true},
+		{"other dokumen", service, dokumen.ID + 1, expires, signature, false},
+		{"extended expiry", service, dokumen.ID, expires + 3600, signature, false},
+		{"tampered signature", service, dokumen.ID, expires, string(tampered), false},
+		{"empty signature", service, dokumen.ID, expires, "", false},
+		{"expired", service, dokumen.ID, past, pastSignature, false},
+		{"other signing key", otherService, dokumen.ID, expires, signature, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.service.VerifyDownloadSignature(ctx, tt.id, tt.expires, tt.signature)
+		if tt.valid && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: got %v, want ErrInvalidSignature", tt.name, err)
+		}
+	}
+}
+
+func TestCreateDownloadURLLifetime(t *testing.T) {
+	service, _ := newTestDokumenService(t)
+	ctx := context.Background()
+
+	dokumen, err := service.UploadDokumen(ctx, "berkas.pdf", bytes.NewReader(pdfContent), "apl01", 1)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	for _, ttl := range []time.Duration{0, -time.Minute, maxDownloadURLTTL + time.Second} {
+		if _, _, err := service.CreateDownloadURL(ctx, dokumen.ID, ttl); err == nil {
+			t.Errorf("CreateDownloadURL accepted a lifetime of %s", ttl)
+		}
+	}
+	if _, _, err := service.CreateDownloadURL(ctx, dokumen.ID+1, time.Hour); err == nil {
+		t.Errorf("CreateDownloadURL succeeded for a missing dokumen")
+	}
+}
+
+func TestDeleteDokumenKeepsReferencedFiles(t *testing.T) {
+	service, dir := newTestDokumenService(t)
+	ctx := context.Background()
+
+	referenced, err := service.UploadDokumen(ctx, "bukti.pdf", bytes.NewReader(pdfContent), "apl01", 1)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	unused, err := service.UploadDokumen(ctx, "lama.pdf", bytes.NewReader(pdfContent), "apl01", 1)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	service.(*dokumenService).dokumenRepo.(*memoryDokumenRepository).referenced[referenced.ID] = true
+
+	err = service.DeleteDokumen(ctx, referenced.ID)
+	if !errors.Is(err, ErrDokumenInUse) {
+		t.Fatalf("deleting a referenced dokumen: got %v, want ErrDokumenInUse", err)
+	}
+	if _, err := service.GetDokumen(ctx, referenced.ID); err != nil {
+		t.Errorf("referenced dokumen was removed: %v", err)
+	}
+
+	err = service.DeleteDokumen(ctx, unused.ID)
+	if err != nil {
+		t.Fatalf("deleting an unused dokumen: %v", err)
+	}
+	if n := storedFiles(t, dir); n != 1 {
+		t.Errorf("storage holds %d files, want only the referenced one", n)
+	}
+}
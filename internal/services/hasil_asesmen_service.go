@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrHasilAsesmenNotFound = errors.New("hasil asesmen not found")
+	ErrHasilAsesmenLocked   = errors.New("hasil asesmen has been signed off and can no longer be changed")
+	ErrAsesorNotAssigned    = errors.New("asesor is not assigned to this jadwal")
+	ErrAsesiNotAssigned     = errors.New("asesi is not a participant assigned to this asesor on the jadwal")
+)
+
+var metodeAsesmen = map[string]bool{
+	models.MetodeObservasi:  true,
+	models.MetodeTertulis:   true,
+	models.MetodeLisan:      true,
+	models.MetodePortofolio: true,
+}
+
+type HasilAsesmenService interface {
+	CreateHasil(ctx context.Context, asesorUserID uint, data *models.HasilAsesmen) (*models.HasilAsesmen, error)
+	UpdateHasil(ctx context.Context, id, asesorUserID uint, data *models.HasilAsesmen) (*models.HasilAsesmen, error)
+	SignOffHasil(ctx context.Context, id, asesorUserID uint) (*models.HasilAsesmen, error)
+	GetHasilByID(ctx context.Context, id, userID uint, role string) (*models.HasilAsesmen, error)
+	GetHasilList(ctx context.Context, userID uint, role string) ([]models.HasilAsesmen, error)
+}
+
+type hasilAsesmenService struct {
+	hasilRepo   repositories.HasilAsesmenRepository
+	jadwalRepo  repositories.JadwalRepository
+	apl01Repo   repositories.APL01Repository
+	unitRepo    repositories.UnitKompetensiRepository
+	asesorRepo  repositories.AsesorRepository
+	konflikRepo repositories.KonflikKepentinganRepository
+}
+
+func NewHasilAsesmenService(
+	hasilRepo repositories.HasilAsesmenRepository,
+	jadwalRepo repositories.JadwalRepository,
+	apl01Repo repositories.APL01Repository,
+	unitRepo repositories.UnitKompetensiRepository,
+	asesorRepo repositories.AsesorRepository,
+	konflikRepo repositories.KonflikKepentinganRepository,
+) HasilAsesmenService {
+	return &hasilAsesmenService{
+		hasilRepo:   hasilRepo,
+		jadwalRepo:  jadwalRepo,
+		apl01Repo:   apl01Repo,
+		unitRepo:    unitRepo,
+		asesorRepo:  asesorRepo,
+		konflikRepo: konflikRepo,
+	}
+}
+
+func (s *hasilAsesmenService) CreateHasil(ctx context.Context, asesorUserID uint, data *models.HasilAsesmen) (*models.HasilAsesmen, error) {
+	ctx, span := startSpan(ctx, "HasilAsesmenService.CreateHasil")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByUserID(ctx, asesorUserID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	jadwal, err := s.jadwalRepo.FindByID(ctx, data.JadwalID)
+	if err != nil {
+		return nil, fmt.Errorf("jadwal not found: %w", err)
+	}
+
+	if !jadwalHasAsesor(jadwal, asesor.ID) {
+		return nil, ErrAsesorNotAssigned
+	}
+
+	apl01, err := s.apl01Repo.FindByID(ctx, data.APL01ID)
+	if err != nil {
+		return nil, ErrAPL01NotFound
+	}
+
+	if apl01.Status != models.StatusAPL01Disetujui {
+		return nil, errors.New("apl01 must be approved before recording a result")
+	}
+
+	if apl01.KompetensiID != jadwal.KompetensiID {
+		return nil, errors.New("apl01 and jadwal are for different kompetensi")
+	}
+
+	// Only the asesor given the asesi by the accepted assignment may assess them
+	peserta, err := s.jadwalRepo.FindPesertaByAPL01(ctx, jadwal.ID, apl01.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAsesiNotAssigned
+		}
+		return nil, fmt.Errorf("failed to load jadwal peserta: %w", err)
+	}
+	if peserta.AsesorID == nil || *peserta.AsesorID != asesor.ID {
+		return nil, ErrAsesiNotAssigned
+	}
+
+	err = ensureNoConflict(ctx, s.konflikRepo, s.apl01Repo, asesor, apl01.AsesiID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateUnits(ctx, jadwal.KompetensiID, data, false)
+	if err != nil {
+		return nil, err
+	}
+
+	hasil := &models.HasilAsesmen{
+		JadwalID:      jadwal.ID,
+		APL01ID:       apl01.ID,
+		AsesiID:       apl01.AsesiID,
+		KompetensiID:  jadwal.KompetensiID,
+		AsesorID:      asesor.ID,
+		MetodeAsesmen: data.MetodeAsesmen,
+		Rekomendasi:   data.Rekomendasi,
+		Catatan:       data.Catatan,
+		Status:        models.StatusHasilDraft,
+		Units:         data.Units,
+	}
+
+	err = s.hasilRepo.Create(ctx, hasil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hasil asesmen: %w", err)
+	}
+
+	return s.hasilRepo.FindByID(ctx, hasil.ID)
+}
+
+func (s *hasilAsesmenService) UpdateHasil(ctx context.Context, id, asesorUserID uint, data *models.HasilAsesmen) (*models.HasilAsesmen, error) {
+	ctx, span := startSpan(ctx, "HasilAsesmenService.UpdateHasil")
+	defer span.End()
+
+	hasil, err := s.findForAsesor(ctx, id, asesorUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasil.Status != models.StatusHasilDraft {
+		return nil, ErrHasilAsesmenLocked
+	}
+
+	err = s.validateUnits(ctx, hasil.KompetensiID, data, false)
+	if err != nil {
+		return nil, err
+	}
+
+	hasil.MetodeAsesmen = data.MetodeAsesmen
+	hasil.Rekomendasi = data.Rekomendasi
+	hasil.Catatan = data.Catatan
+	hasil.Units = data.Units
+
+	err = s.hasilRepo.Update(ctx, hasil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrHasilAsesmenLocked
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update hasil asesmen: %w", err)
+	}
+
+	return s.hasilRepo.FindByID(ctx, id)
+}
+
+func (s *hasilAsesmenService) SignOffHasil(ctx context.Context, id, asesorUserID uint) (*models.HasilAsesmen, error) {
+	ctx, span := startSpan(ctx, "HasilAsesmenService.SignOffHasil")
+	defer span.End()
+
+	hasil, err := s.findForAsesor(ctx, id, asesorUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasil.Status != models.StatusHasilDraft {
+		return nil, ErrHasilAsesmenLocked
+	}
+
+	err = s.validateUnits(ctx, hasil.KompetensiID, hasil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.hasilRepo.SignOff(ctx, id, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrHasilAsesmenLocked
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to sign off hasil asesmen: %w", err)
+	}
+
+	return s.hasilRepo.FindByID(ctx, id)
+}
+
+func (s *hasilAsesmenService) GetHasilByID(ctx context.Context, id, userID uint, role string) (*models.HasilAsesmen, error) {
+	ctx, span := startSpan(ctx, "HasilAsesmenService.GetHasilByID")
+	defer span.End()
+
+	hasil, err := s.hasilRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrHasilAsesmenNotFound
+	}
+
+	switch role {
+	case models.RoleAdmin:
+		return hasil, nil
+	case models.RoleAsesi:
+		if hasil.AsesiID == userID {
+			return hasil, nil
+		}
+	case models.RoleAsesor:
+		asesor, err := s.asesorRepo.FindByUserID(ctx, userID)
+		if err == nil && hasil.AsesorID == asesor.ID {
+			return hasil, nil
+		}
+	}
+
+	return nil, ErrHasilAsesmenNotFound
+}
+
+func (s *hasilAsesmenService) GetHasilList(ctx context.Context, userID uint, role string) ([]models.HasilAsesmen, error) {
+	ctx, span := startSpan(ctx, "HasilAsesmenService.GetHasilList")
+	defer span.End()
+
+	switch role {
+	case models.RoleAdmin:
+		return s.hasilRepo.FindAll(ctx, 0, 0)
+	case models.RoleAsesi:
+		return s.hasilRepo.FindAll(ctx, userID, 0)
+	case models.RoleAsesor:
+		asesor, err := s.asesorRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("asesor not found: %w", err)
+		}
+		return s.hasilRepo.FindAll(ctx, 0, asesor.ID)
+	default:
+		return []models.HasilAsesmen{}, nil
+	}
+}
+
+// findForAsesor loads a result only if the caller is its asesor and is still
+// assigned to the jadwal it belongs to.
+func (s *hasilAsesmenService) findForAsesor(ctx context.Context, id, asesorUserID uint) (*models.HasilAsesmen, error) {
+	hasil, err := s.GetHasilByID(ctx, id, asesorUserID, models.RoleAsesor)
+	if err != nil {
+		return nil, err
+	}
+
+	jadwal, err := s.jadwalRepo.FindByID(ctx, hasil.JadwalID)
+	if err != nil {
+		return nil, fmt.Errorf("jadwal not found: %w", err)
+	}
+
+	if !jadwalHasAsesor(jadwal, hasil.AsesorID) {
+		return nil, ErrAsesorNotAssigned
+	}
+
+	return hasil, nil
+}
+
+// validateUnits checks methods and verdicts against the unit structure of the
+// kompetensi. A final check also requires every unit to have a verdict and the
+// recommendation to be consistent with them.
+func (s *hasilAsesmenService) validateUnits(ctx context.Context, kompetensiID uint, data *models.HasilAsesmen, final bool) error {
+	for _, metode := range data.MetodeAsesmen {
+		if !metodeAsesmen[metode] {
+			return fmt.Errorf("invalid assessment method: %s", metode)
+		}
+	}
+
+	if data.Rekomendasi != "" && data.Rekomendasi != models.KeputusanKompeten && data.Rekomendasi != models.KeputusanBelumKompeten {
+		return fmt.Errorf("invalid recommendation: %s", data.Rekomendasi)
+	}
+
+	units, err := s.unitRepo.FindByKompetensiID(ctx, kompetensiID)
+	if err != nil {
+		return fmt.Errorf("failed to load competency structure: %w", err)
+	}
+
+	known := make(map[uint]bool, len(units))
+	for _, unit := range units {
+		known[unit.ID] = true
+	}
+
+	seen := make(map[uint]bool, len(data.Units))
+	allKompeten := true
+	for _, verdict := range data.Units {
+		if !known[verdict.UnitKompetensiID] {
+			return fmt.Errorf("unit kompetensi %d does not belong to this kompetensi", verdict.UnitKompetensiID)
+		}
+		if seen[verdict.UnitKompetensiID] {
+			return fmt.Errorf("unit kompetensi %d is listed more than once", verdict.UnitKompetensiID)
+		}
+		if verdict.Keputusan != models.KeputusanKompeten && verdict.Keputusan != models.KeputusanBelumKompeten {
+			return fmt.Errorf("invalid verdict: %s", verdict.Keputusan)
+		}
+		seen[verdict.UnitKompetensiID] = true
+		allKompeten = allKompeten && verdict.Keputusan == models.KeputusanKompeten
+	}
+
+	if !final {
+		return nil
+	}
+
+	if len(units) == 0 || len(seen) != len(units) {
+		return errors.New("every unit kompetensi must have a verdict before sign-off")
+	}
+
+	if len(data.MetodeAsesmen) == 0 {
+		return errors.New("at least one assessment method is required before sign-off")
+	}
+
+	if data.Rekomendasi == "" {
+		return errors.New("a final recommendation is required before sign-off")
+	}
+
+	if data.Rekomendasi == models.KeputusanKompeten && !allKompeten {
+		return errors.New("recommendation K requires every unit to be K")
+	}
+
+	return nil
+}
+
+func jadwalHasAsesor(jadwal *models.JadwalAsesmen, asesorID uint) bool {
+	for _, asesor := range jadwal.Asesor {
+		if asesor.ID == asesorID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+)
+
+type JadwalService interface {
+	CreateJadwal(ctx context.Context, kompetensiID uint, tukID *uint, tanggalMulai, tanggalSelesai time.Time, lokasi string) (*models.JadwalAsesmen, error)
+	GetJadwalByID(ctx context.Context, id uint) (*models.JadwalAsesmen, error)
+	GetAllJadwal(ctx context.Context) ([]models.JadwalAsesmen, error)
+	AssignAsesor(ctx context.Context, jadwalID, asesorID uint) (*models.JadwalAsesmen, error)
+	UnassignAsesor(ctx context.Context, jadwalID, asesorID uint) (*models.JadwalAsesmen, error)
+}
+
+type jadwalService struct {
+	jadwalRepo           repositories.JadwalRepository
+	tukRepo              repositories.TUKRepository
+	asesorRepo           repositories.AsesorRepository
+	kompetensiRepo       repositories.KompetensiRepository
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository
+}
+
+func NewJadwalService(
+	jadwalRepo repositories.JadwalRepository,
+	tukRepo repositories.TUKRepository,
+	asesorRepo repositories.AsesorRepository,
+	kompetensiRepo repositories.KompetensiRepository,
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+) JadwalService {
+	return &jadwalService{
+		jadwalRepo:           jadwalRepo,
+		tukRepo:              tukRepo,
+		asesorRepo:           asesorRepo,
+		kompetensiRepo:       kompetensiRepo,
+		asesorKompetensiRepo: asesorKompetensiRepo,
+	}
+}
+
+func (s *jadwalService) CreateJadwal(ctx context.Context, kompetensiID uint, tukID *uint, tanggalMulai, tanggalSelesai time.Time, lokasi string) (*models.JadwalAsesmen, error) {
+	ctx, span := startSpan(ctx, "JadwalService.CreateJadwal")
+	defer span.End()
+
+	if tanggalSelesai.Before(tanggalMulai) {
+		return nil, errors.New("schedule end must not be before its start")
+	}
+
+	kompetensi, err := s.kompetensiRepo.FindByID(ctx, kompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("kompetensi not found: %w", err)
+	}
+
+	jadwal := &models.JadwalAsesmen{
+		KompetensiID:   kompetensi.ID,
+		TanggalMulai:   tanggalMulai,
+		TanggalSelesai: tanggalSelesai,
+		Lokasi:         lokasi,
+	}
+
+	if tukID != nil {
+		tuk, err := s.tukRepo.FindByID(ctx, *tukID)
+		if err != nil {
+			return nil, fmt.Errorf("tuk not found: %w", err)
+		}
+
+		// A new jadwal has no peserta yet
+		err = ensureTUKSuitable(tuk, kompetensi.ID, tanggalMulai, tanggalSelesai, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		jadwal.TUKID = &tuk.ID
+		if jadwal.Lokasi == "" {
+			jadwal.Lokasi = tuk.Alamat
+		}
+	}
+
+	err = s.jadwalRepo.Create(ctx, jadwal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jadwal: %w", err)
+	}
+
+	return s.jadwalRepo.FindByID(ctx, jadwal.ID)
+}
+
+func (s *jadwalService) GetJadwalByID(ctx context.Context, id uint) (*models.JadwalAsesmen, error) {
+	ctx, span := startSpan(ctx, "JadwalService.GetJadwalByID")
+	defer span.End()
+
+	return s.jadwalRepo.FindByID(ctx, id)
+}
+
+func (s *jadwalService) GetAllJadwal(ctx context.Context) ([]models.JadwalAsesmen, error) {
+	ctx, span := startSpan(ctx, "JadwalService.GetAllJadwal")
+	defer span.End()
+
+	return s.jadwalRepo.FindAll(ctx)
+}
+
+func (s *jadwalService) AssignAsesor(ctx context.Context, jadwalID, asesorID uint) (*models.JadwalAsesmen, error) {
+	ctx, span := startSpan(ctx, "JadwalService.AssignAsesor")
+	defer span.End()
+
+	jadwal, err := s.jadwalRepo.FindByID(ctx, jadwalID)
+	if err != nil {
+		return nil, fmt.Errorf("jadwal not found: %w", err)
+	}
+
+	asesor, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	err = ensureAsesorEligible(ctx, s.asesorKompetensiRepo, asesor, jadwal.KompetensiID, jadwal.TanggalMulai, jadwal.TanggalSelesai)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.jadwalRepo.AddAsesor(ctx, jadwal, asesor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign asesor: %w", err)
+	}
+
+	return s.jadwalRepo.FindByID(ctx, jadwalID)
+}
+
+func (s *jadwalService) UnassignAsesor(ctx context.Context, jadwalID, asesorID uint) (*models.JadwalAsesmen, error) {
+	ctx, span := startSpan(ctx, "JadwalService.UnassignAsesor")
+	defer span.End()
+
+	jadwal, err := s.jadwalRepo.FindByID(ctx, jadwalID)
+	if err != nil {
+		return nil, fmt.Errorf("jadwal not found: %w", err)
+	}
+
+	asesor, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	err = s.jadwalRepo.RemoveAsesor(ctx, jadwal, asesor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign asesor: %w", err)
+	}
+
+	return s.jadwalRepo.FindByID(ctx, jadwalID)
+}
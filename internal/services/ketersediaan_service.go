@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+const maxSlotRange = 92 * 24 * time.Hour
+
+var ErrKetersediaanNotFound = errors.New("ketersediaan not found")
+
+// Slot is a half-open period from Mulai to Selesai.
+type Slot struct {
+	Mulai   time.Time `json:"mulai"`
+	Selesai time.Time `json:"selesai"`
+}
+
+// AsesorSlots lists the free periods of one qualified asesor.
+type AsesorSlots struct {
+	Asesor models.Asesor `json:"asesor"`
+	Slots  []Slot        `json:"slots"`
+}
+
+type KetersediaanService interface {
+	AddKetersediaan(ctx context.Context, asesorID uint, jenis string, mulai, selesai time.Time, keterangan string) (*models.AsesorKetersediaan, error)
+	DeleteKetersediaan(ctx context.Context, asesorID, id uint) error
+	GetKetersediaan(ctx context.Context, asesorID uint, from, to time.Time) ([]models.AsesorKetersediaan, error)
+	GetFreeSlots(ctx context.Context, kompetensiID uint, from, to time.Time) ([]AsesorSlots, error)
+	FindFreeAsesors(ctx context.Context, kompetensiID uint, start, end time.Time, ignoreJadwalID uint) ([]models.Asesor, error)
+}
+
+type ketersediaanService struct {
+	ketersediaanRepo     repositories.AsesorKetersediaanRepository
+	asesorRepo           repositories.AsesorRepository
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository
+	jadwalRepo           repositories.JadwalRepository
+}
+
+func NewKetersediaanService(
+	ketersediaanRepo repositories.AsesorKetersediaanRepository,
+	asesorRepo repositories.AsesorRepository,
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+	jadwalRepo repositories.JadwalRepository,
+) KetersediaanService {
+	return &ketersediaanService{
+		ketersediaanRepo:     ketersediaanRepo,
+		asesorRepo:           asesorRepo,
+		asesorKompetensiRepo: asesorKompetensiRepo,
+		jadwalRepo:           jadwalRepo,
+	}
+}
+
+func (s *ketersediaanService) AddKetersediaan(ctx context.Context, asesorID uint, jenis string, mulai, selesai time.Time, keterangan string) (*models.AsesorKetersediaan, error) {
+	ctx, span := startSpan(ctx, "KetersediaanService.AddKetersediaan")
+	defer span.End()
+
+	if !selesai.After(mulai) {
+		return nil, errors.New("period end must be after its start")
+	}
+
+	if jenis != models.JenisKetersediaanTersedia && jenis != models.JenisKetersediaanTidakTersedia {
+		return nil, fmt.Errorf("invalid availability type: %s", jenis)
+	}
+
+	_, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	ketersediaan := &models.AsesorKetersediaan{
+		AsesorID:   asesorID,
+		Jenis:      jenis,
+		Mulai:      mulai,
+		Selesai:    selesai,
+		Keterangan: keterangan,
+	}
+
+	err = s.ketersediaanRepo.Create(ctx, ketersediaan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ketersediaan: %w", err)
+	}
+
+	return ketersediaan, nil
+}
+
+func (s *ketersediaanService) DeleteKetersediaan(ctx context.Context, asesorID, id uint) error {
+	ctx, span := startSpan(ctx, "KetersediaanService.DeleteKetersediaan")
+	defer span.End()
+
+	err := s.ketersediaanRepo.Delete(ctx, asesorID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrKetersediaanNotFound
+	}
+	return err
+}
+
+func (s *ketersediaanService) GetKetersediaan(ctx context.Context, asesorID uint, from, to time.Time) ([]models.AsesorKetersediaan, error) {
+	ctx, span := startSpan(ctx, "KetersediaanService.GetKetersediaan")
+	defer span.End()
+
+	if !to.After(from) {
+		return nil, errors.New("range end must be after its start")
+	}
+	return s.ketersediaanRepo.FindOverlapping(ctx, []uint{asesorID}, from, to)
+}
+
+// GetFreeSlots returns, per asesor qualified for the kompetensi, the periods
+// in from..to that they declared available, minus blackouts and schedules
+// they are already assigned to, clipped to their license and certification
+// validity.
+func (s *ketersediaanService) GetFreeSlots(ctx context.Context, kompetensiID uint, from, to time.Time) ([]AsesorSlots, error) {
+	ctx, span := startSpan(ctx, "KetersediaanService.GetFreeSlots")
+	defer span.End()
+
+	if !to.After(from) {
+		return nil, errors.New("range end must be after its start")
+	}
+
+	if to.Sub(from) > maxSlotRange {
+		return nil, errors.New("range must not exceed 92 days")
+	}
+
+	return s.freeSlots(ctx, kompetensiID, from, to, 0)
+}
+
+// FindFreeAsesors returns the qualified asesors with a single free slot
+// covering the whole period. Assignments to ignoreJadwalID do not count as
+// busy, so a jadwal can be re-planned.
+func (s *ketersediaanService) FindFreeAsesors(ctx context.Context, kompetensiID uint, start, end time.Time, ignoreJadwalID uint) ([]models.Asesor, error) {
+	ctx, span := startSpan(ctx, "KetersediaanService.FindFreeAsesors")
+	defer span.End()
+
+	slots, err := s.freeSlots(ctx, kompetensiID, start, end, ignoreJadwalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var asesors []models.Asesor
+	for _, item := range slots {
+		for _, slot := range item.Slots {
+			if !slot.Mulai.After(start) && !slot.Selesai.Before(end) {
+				asesors = append(asesors, item.Asesor)
+				break
+			}
+		}
+	}
+	return asesors, nil
+}
+
+func (s *ketersediaanService) freeSlots(ctx context.Context, kompetensiID uint, from, to time.Time, ignoreJadwalID uint) ([]AsesorSlots, error) {
+	sertifikasi, err := s.asesorKompetensiRepo.FindByKompetensiID(ctx, kompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find qualified asesors: %w", err)
+	}
+
+	qualified := make(map[uint]Slot)
+	asesors := make(map[uint]models.Asesor)
+	var asesorIDs []uint
+	for _, item := range sertifikasi {
+		asesor := item.Asesor
+		if asesor.StatusLisensi != models.StatusLisensiAktif || asesor.TanggalKadaluarsaLisensi == nil ||
+			item.BerlakuMulai == nil || item.BerlakuSampai == nil {
+			continue
+		}
+
+		window := clipSlot(Slot{Mulai: from, Selesai: to}, Slot{
+			Mulai:   *item.BerlakuMulai,
+			Selesai: minTime(*item.BerlakuSampai, *asesor.TanggalKadaluarsaLisensi).AddDate(0, 0, 1),
+		})
+		if window == nil {
+			continue
+		}
+
+		qualified[asesor.ID] = *window
+		asesors[asesor.ID] = asesor
+		asesorIDs = append(asesorIDs, asesor.ID)
+	}
+
+	result := []AsesorSlots{}
+	if len(asesorIDs) == 0 {
+		return result, nil
+	}
+
+	periods, err := s.ketersediaanRepo.FindOverlapping(ctx, asesorIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load availability: %w", err)
+	}
+
+	jadwal, err := s.jadwalRepo.FindOverlappingForAsesors(ctx, asesorIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schedules: %w", err)
+	}
+
+	available := make(map[uint][]Slot)
+	busy := make(map[uint][]Slot)
+	for _, period := range periods {
+		slot := Slot{Mulai: period.Mulai, Selesai: period.Selesai}
+		if period.Jenis == models.JenisKetersediaanTersedia {
+			available[period.AsesorID] = append(available[period.AsesorID], slot)
+		} else {
+			busy[period.AsesorID] = append(busy[period.AsesorID], slot)
+		}
+	}
+	for _, j := range jadwal {
+		if j.ID == ignoreJadwalID {
+			continue
+		}
+		for _, asesor := range j.Asesor {
+			busy[asesor.ID] = append(busy[asesor.ID], Slot{Mulai: j.TanggalMulai, Selesai: j.TanggalSelesai})
+		}
+	}
+
+	for _, asesorID := range asesorIDs {
+		var slots []Slot
+		for _, slot := range mergeSlots(available[asesorID]) {
+			if clipped := clipSlot(slot, qualified[asesorID]); clipped != nil {
+				slots = append(slots, *clipped)
+			}
+		}
+
+		slots = subtractSlots(slots, mergeSlots(busy[asesorID]))
+		if len(slots) == 0 {
+			continue
+		}
+
+		result = append(result, AsesorSlots{Asesor: asesors[asesorID], Slots: slots})
+	}
+
+	return result, nil
+}
+
+// mergeSlots sorts the slots and joins the ones that overlap or touch.
+func mergeSlots(slots []Slot) []Slot {
+	if len(slots) == 0 {
+		return nil
+	}
+
+	sorted := append([]Slot(nil), slots...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Mulai.Before(sorted[j].Mulai) })
+
+	merged := []Slot{sorted[0]}
+	for _, slot := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !slot.Mulai.After(last.Selesai) {
+			if slot.Selesai.After(last.Selesai) {
+				last.Selesai = slot.Selesai
+			}
+			continue
+		}
+		merged = append(merged, slot)
+	}
+	return merged
+}
+
+// subtractSlots removes the merged cuts from the merged slots.
+func subtractSlots(slots, cuts []Slot) []Slot {
+	var result []Slot
+	for _, slot := range slots {
+		remaining := slot
+		empty := false
+		for _, cut := range cuts {
+			if !cut.Selesai.After(remaining.Mulai) || !cut.Mulai.Before(remaining.Selesai) {
+				continue
+			}
+			if cut.Mulai.After(remaining.Mulai) {
+				result = append(result, Slot{Mulai: remaining.Mulai, Selesai: cut.Mulai})
+			}
+			if !cut.Selesai.Before(remaining.Selesai) {
+				empty = true
+				break
+			}
+			remaining.Mulai = cut.Selesai
+		}
+		if !empty {
+			result = append(result, remaining)
+		}
+	}
+	return result
+}
+
+func clipSlot(slot, window Slot) *Slot {
+	clipped := Slot{Mulai: slot.Mulai, Selesai: slot.Selesai}
+	if window.Mulai.After(clipped.Mulai) {
+		clipped.Mulai = window.Mulai
+	}
+	if window.Selesai.Before(clipped.Selesai) {
+		clipped.Selesai = window.Selesai
+	}
+	if !clipped.Selesai.After(clipped.Mulai) {
+		return nil
+	}
+	return &clipped
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
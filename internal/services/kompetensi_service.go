@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+)
+
+// ErrUnitInUse is returned when deleting a unit kompetensi that APL-02
+// answers or assessment results still refer to.
+var ErrUnitInUse = errors.New("unit kompetensi is used by apl02 or hasil asesmen and cannot be deleted")
+
+// KompetensiListing is a kompetensi together with the asesors whose
+// certification for it is currently valid.
+type KompetensiListing struct {
+	models.Kompetensi
+	Asesor []models.Asesor `json:"asesor"`
+}
+
+type KompetensiService interface {
+	GetAllKompetensi(ctx context.Context) ([]KompetensiListing, error)
+	GetKompetensiByID(ctx context.Context, id uint) (*KompetensiListing, error)
+	CreateUnit(ctx context.Context, kompetensiID uint, unit *models.UnitKompetensi) (*models.UnitKompetensi, error)
+	GetUnits(ctx context.Context, kompetensiID uint) ([]models.UnitKompetensi, error)
+	DeleteUnit(ctx context.Context, kompetensiID, unitID uint) error
+}
+
+type kompetensiService struct {
+	kompetensiRepo       repositories.KompetensiRepository
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository
+	unitRepo             repositories.UnitKompetensiRepository
+}
+
+func NewKompetensiService(
+	kompetensiRepo repositories.KompetensiRepository,
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+	unitRepo repositories.UnitKompetensiRepository,
+) KompetensiService {
+	return &kompetensiService{
+		kompetensiRepo:       kompetensiRepo,
+		asesorKompetensiRepo: asesorKompetensiRepo,
+		unitRepo:             unitRepo,
+	}
+}
+
+func (s *kompetensiService) GetAllKompetensi(ctx context.Context) ([]KompetensiListing, error) {
+	ctx, span := startSpan(ctx, "KompetensiService.GetAllKompetensi")
+	defer span.End()
+
+	kompetensi, err := s.kompetensiRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	validAsesors, err := s.validAsesorsByKompetensi(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listings := make([]KompetensiListing, 0, len(kompetensi))
+	for _, k := range kompetensi {
+		listings = append(listings, KompetensiListing{
+			Kompetensi: k,
+			Asesor:     validAsesors[k.ID],
+		})
+	}
+
+	return listings, nil
+}
+
+func (s *kompetensiService) GetKompetensiByID(ctx context.Context, id uint) (*KompetensiListing, error) {
+	ctx, span := startSpan(ctx, "KompetensiService.GetKompetensiByID")
+	defer span.End()
+
+	kompetensi, err := s.kompetensiRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := s.asesorKompetensiRepo.FindValidByKompetensiAt(ctx, kompetensi.ID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find certified asesors: %w", err)
+	}
+
+	listing := &KompetensiListing{Kompetensi: *kompetensi}
+	for _, ak := range valid {
+		listing.Asesor = append(listing.Asesor, ak.Asesor)
+	}
+
+	return listing, nil
+}
+
+func (s *kompetensiService) CreateUnit(ctx context.Context, kompetensiID uint, unit *models.UnitKompetensi) (*models.UnitKompetensi, error) {
+	ctx, span := startSpan(ctx, "KompetensiService.CreateUnit")
+	defer span.End()
+
+	_, err := s.kompetensiRepo.FindByID(ctx, kompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("kompetensi not found: %w", err)
+	}
+
+	if len(unit.Elemen) == 0 {
+		return nil, errors.New("unit kompetensi must have at least one elemen")
+	}
+
+	unit.ID = 0
+	unit.KompetensiID = kompetensiID
+
+	err = s.unitRepo.Create(ctx, unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unit kompetensi: %w", err)
+	}
+
+	return s.unitRepo.FindByID(ctx, unit.ID)
+}
+
+func (s *kompetensiService) GetUnits(ctx context.Context, kompetensiID uint) ([]models.UnitKompetensi, error) {
+	ctx, span := startSpan(ctx, "KompetensiService.GetUnits")
+	defer span.End()
+
+	_, err := s.kompetensiRepo.FindByID(ctx, kompetensiID)
+	if err != nil {
+		return nil, fmt.Errorf("kompetensi not found: %w", err)
+	}
+
+	return s.unitRepo.FindByKompetensiID(ctx, kompetensiID)
+}
+
+func (s *kompetensiService) DeleteUnit(ctx context.Context, kompetensiID, unitID uint) error {
+	ctx, span := startSpan(ctx, "KompetensiService.DeleteUnit")
+	defer span.End()
+
+	unit, err := s.unitRepo.FindByID(ctx, unitID)
+	if err != nil || unit.KompetensiID != kompetensiID {
+		return errors.New("unit kompetensi not found")
+	}
+
+	referenced, err := s.unitRepo.IsReferenced(ctx, unitID)
+	if err != nil {
+		return fmt.Errorf("failed to check unit kompetensi usage: %w", err)
+	}
+	if referenced {
+		return ErrUnitInUse
+	}
+
+	return s.unitRepo.Delete(ctx, unitID)
+}
+
+func (s *kompetensiService) validAsesorsByKompetensi(ctx context.Context) (map[uint][]models.Asesor, error) {
+	valid, err := s.asesorKompetensiRepo.FindValidAt(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find certified asesors: %w", err)
+	}
+
+	result := make(map[uint][]models.Asesor)
+	for _, ak := range valid {
+		if result[ak.KompetensiID] == nil {
+			result[ak.KompetensiID] = []models.Asesor{}
+		}
+		result[ak.KompetensiID] = append(result[ak.KompetensiID], ak.Asesor)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+)
+
+var (
+	ErrConflictOfInterest = errors.New("asesor has a conflict of interest with the asesi")
+	ErrKonflikNotFound    = errors.New("konflik kepentingan not found")
+)
+
+type KonflikKepentinganService interface {
+	DeclareKonflik(ctx context.Context, asesorUserID, asesiID uint, jenis, keterangan string) (*models.KonflikKepentingan, error)
+	RecordKonflik(ctx context.Context, adminID, asesorID, asesiID uint, jenis, keterangan string) (*models.KonflikKepentingan, error)
+	GetOwnKonflik(ctx context.Context, asesorUserID uint) ([]models.KonflikKepentingan, error)
+	GetKonflikList(ctx context.Context, asesorID, asesiID uint) ([]models.KonflikKepentingan, error)
+	DeleteKonflik(ctx context.Context, id uint) error
+}
+
+type konflikKepentinganService struct {
+	konflikRepo repositories.KonflikKepentinganRepository
+	asesorRepo  repositories.AsesorRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewKonflikKepentinganService(
+	konflikRepo repositories.KonflikKepentinganRepository,
+	asesorRepo repositories.AsesorRepository,
+	userRepo repositories.UserRepository,
+) KonflikKepentinganService {
+	return &konflikKepentinganService{
+		konflikRepo: konflikRepo,
+		asesorRepo:  asesorRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (s *konflikKepentinganService) DeclareKonflik(ctx context.Context, asesorUserID, asesiID uint, jenis, keterangan string) (*models.KonflikKepentingan, error) {
+	ctx, span := startSpan(ctx, "KonflikKepentinganService.DeclareKonflik")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByUserID(ctx, asesorUserID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	return s.record(ctx, asesor.ID, asesiID, jenis, keterangan, asesorUserID)
+}
+
+func (s *konflikKepentinganService) RecordKonflik(ctx context.Context, adminID, asesorID, asesiID uint, jenis, keterangan string) (*models.KonflikKepentingan, error) {
+	ctx, span := startSpan(ctx, "KonflikKepentinganService.RecordKonflik")
+	defer span.End()
+
+	_, err := s.asesorRepo.FindByID(ctx, asesorID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	return s.record(ctx, asesorID, asesiID, jenis, keterangan, adminID)
+}
+
+func (s *konflikKepentinganService) GetOwnKonflik(ctx context.Context, asesorUserID uint) ([]models.KonflikKepentingan, error) {
+	ctx, span := startSpan(ctx, "KonflikKepentinganService.GetOwnKonflik")
+	defer span.End()
+
+	asesor, err := s.asesorRepo.FindByUserID(ctx, asesorUserID)
+	if err != nil {
+		return nil, fmt.Errorf("asesor not found: %w", err)
+	}
+
+	return s.konflikRepo.FindAll(ctx, asesor.ID, 0)
+}
+
+func (s *konflikKepentinganService) GetKonflikList(ctx context.Context, asesorID, asesiID uint) ([]models.KonflikKepentingan, error) {
+	ctx, span := startSpan(ctx, "KonflikKepentinganService.GetKonflikList")
+	defer span.End()
+
+	return s.konflikRepo.FindAll(ctx, asesorID, asesiID)
+}
+
+func (s *konflikKepentinganService) DeleteKonflik(ctx context.Context, id uint) error {
+	ctx, span := startSpan(ctx, "KonflikKepentinganService.DeleteKonflik")
+	defer span.End()
+
+	_, err := s.konflikRepo.FindByID(ctx, id)
+	if err != nil {
+		return ErrKonflikNotFound
+	}
+
+	return s.konflikRepo.Delete(ctx, id)
+}
+
+func (s *konflikKepentinganService) record(ctx context.Context, asesorID, asesiID uint, jenis, keterangan string, actorID uint) (*models.KonflikKepentingan, error) {
+	asesi, err := s.userRepo.FindByID(ctx, asesiID)
+	if err != nil || asesi.Role != models.RoleAsesi {
+		return nil, errors.New("asesi not found")
+	}
+
+	konflik := &models.KonflikKepentingan{
+		AsesorID:    asesorID,
+		AsesiID:     asesi.ID,
+		Jenis:       jenis,
+		Sumber:      models.SumberKonflikDeklarasi,
+		Keterangan:  keterangan,
+		DicatatOleh: &actorID,
+	}
+
+	err = s.konflikRepo.Create(ctx, konflik)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record konflik kepentingan: %w", err)
+	}
+
+	if konflik.ID != 0 {
+		return konflik, nil
+	}
+
+	// The same conflict was already on record for the pair
+	existing, err := s.konflikRepo.FindByPair(ctx, asesorID, asesi.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range existing {
+		if existing[i].Jenis == jenis {
+			return &existing[i], nil
+		}
+	}
+
+	return nil, errors.New("failed to record konflik kepentingan")
+}
+
+// ensureNoConflict rejects pairing an asesor with an asesi when a conflict of
+// interest is on record, or when the asesor's instansi matches an institution
+// the asesi listed in any APL-01. A detected conflict is only reported; staff
+// who want it kept on record add it through RecordKonflik.
+func ensureNoConflict(
+	ctx context.Context,
+	konflikRepo repositories.KonflikKepentinganRepository,
+	apl01Repo repositories.APL01Repository,
+	asesor *models.Asesor,
+	asesiID uint,
+) error {
+	konflik, err := konflikRepo.FindByPair(ctx, asesor.ID, asesiID)
+	if err != nil {
+		return fmt.Errorf("failed to load konflik kepentingan: %w", err)
+	}
+	if len(konflik) > 0 {
+		return fmt.Errorf("%w (%s)", ErrConflictOfInterest, konflik[0].Jenis)
+	}
+
+	instansi := normalizeInstansi(asesor.Instansi)
+	if instansi == "" {
+		return nil
+	}
+
+	apl01s, err := apl01Repo.FindByAsesiID(ctx, asesiID)
+	if err != nil {
+		return fmt.Errorf("failed to load apl01: %w", err)
+	}
+
+	for _, apl01 := range apl01s {
+		if normalizeInstansi(apl01.NamaInstitusi) != instansi {
+			continue
+		}
+
+		return fmt.Errorf("%w (%s: asesor and asesi both work at %s)", ErrConflictOfInterest, models.JenisKonflikPekerjaan, apl01.NamaInstitusi)
+	}
+
+	return nil
+}
+
+// normalizeInstansi makes organization names comparable regardless of case
+// and spacing.
+func normalizeInstansi(nama string) string {
+	return strings.Join(strings.Fields(strings.ToLower(nama)), " ")
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lsp-api/internal/bnsp"
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+)
+
+var ErrInvalidPeriode = errors.New("periode must be bulan, tahun or semua")
+
+type LaporanService interface {
+	GetStatistikAsesor(ctx context.Context, from, to time.Time, periode string) ([]models.StatistikAsesor, error)
+	GetStatistikKompetensi(ctx context.Context, from, to time.Time) ([]models.StatistikKompetensi, error)
+	GetAsesorIdle(ctx context.Context, from, to time.Time) ([]models.AsesorIdle, error)
+	ValidateBNSP(ctx context.Context, from, to time.Time) ([]bnsp.Masalah, error)
+	ExportBNSP(ctx context.Context, from, to time.Time, format string) ([]byte, []bnsp.Masalah, error)
+}
+
+type laporanService struct {
+	laporanRepo repositories.LaporanRepository
+}
+
+func NewLaporanService(laporanRepo repositories.LaporanRepository) LaporanService {
+	return &laporanService{
+		laporanRepo: laporanRepo,
+	}
+}
+
+// GetStatistikAsesor counts the signed results of each asesor in the
+// half-open range [from, to), optionally split by month or year.
+func (s *laporanService) GetStatistikAsesor(ctx context.Context, from, to time.Time, periode string) ([]models.StatistikAsesor, error) {
+	ctx, span := startSpan(ctx, "LaporanService.GetStatistikAsesor")
+	defer span.End()
+
+	switch periode {
+	case "":
+		periode = repositories.PeriodeBulan
+	case repositories.PeriodeBulan, repositories.PeriodeTahun, repositories.PeriodeSemua:
+	default:
+		return nil, ErrInvalidPeriode
+	}
+
+	err := validateLaporanRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statistik, err := s.laporanRepo.StatistikAsesor(ctx, from, to, periode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate asesor statistics: %w", err)
+	}
+
+	for i := range statistik {
+		statistik[i].TingkatKelulusan = tingkatKelulusan(statistik[i].JumlahKompeten, statistik[i].JumlahAsesmen)
+	}
+
+	return statistik, nil
+}
+
+func (s *laporanService) GetStatistikKompetensi(ctx context.Context, from, to time.Time) ([]models.StatistikKompetensi, error) {
+	ctx, span := startSpan(ctx, "LaporanService.GetStatistikKompetensi")
+	defer span.End()
+
+	err := validateLaporanRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statistik, err := s.laporanRepo.StatistikKompetensi(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate kompetensi statistics: %w", err)
+	}
+
+	for i := range statistik {
+		statistik[i].TingkatKelulusan = tingkatKelulusan(statistik[i].JumlahKompeten, statistik[i].JumlahAsesmen)
+	}
+
+	return statistik, nil
+}
+
+func (s *laporanService) GetAsesorIdle(ctx context.Context, from, to time.Time) ([]models.AsesorIdle, error) {
+	ctx, span := startSpan(ctx, "LaporanService.GetAsesorIdle")
+	defer span.End()
+
+	err := validateLaporanRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	idle, err := s.laporanRepo.AsesorIdle(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find idle asesors: %w", err)
+	}
+
+	return idle, nil
+}
+
+// ValidateBNSP lists the results in [from, to) that miss data the regulator
+// requires.
+func (s *laporanService) ValidateBNSP(ctx context.Context, from, to time.Time) ([]bnsp.Masalah, error) {
+	ctx, span := startSpan(ctx, "LaporanService.ValidateBNSP")
+	defer span.End()
+
+	baris, err := s.barisBNSP(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return bnsp.Validate(baris), nil
+}
+
+// ExportBNSP renders the results in [from, to) in the BNSP reporting layout.
+// Nothing is rendered while mandatory data is missing; the problems are
+// returned instead so they can be fixed first.
+func (s *laporanService) ExportBNSP(ctx context.Context, from, to time.Time, format string) ([]byte, []bnsp.Masalah, error) {
+	ctx, span := startSpan(ctx, "LaporanService.ExportBNSP")
+	defer span.End()
+
+	if format != bnsp.FormatCSV && format != bnsp.FormatXLSX {
+		return nil, nil, errors.New("format must be csv or xlsx")
+	}
+
+	baris, err := s.barisBNSP(ctx, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	masalah := bnsp.Validate(baris)
+	if len(masalah) > 0 {
+		return nil, masalah, nil
+	}
+
+	var buf bytes.Buffer
+	err = bnsp.Write(&buf, format, baris)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render bnsp report: %w", err)
+	}
+
+	return buf.Bytes(), nil, nil
+}
+
+func (s *laporanService) barisBNSP(ctx context.Context, from, to time.Time) ([]models.BarisBNSP, error) {
+	err := validateLaporanRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	baris, err := s.laporanRepo.BarisBNSP(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bnsp report data: %w", err)
+	}
+
+	return baris, nil
+}
+
+func validateLaporanRange(from, to time.Time) error {
+	if !to.After(from) {
+		return errors.New("to must not be before from")
+	}
+	return nil
+}
+
+// tingkatKelulusan is the share of results recommended kompeten, rounded to
+// four decimal places.
+func tingkatKelulusan(kompeten, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(kompeten*10000/total) / 10000
+}
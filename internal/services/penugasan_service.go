@@ -0,0 +1,467 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"lsp-api/internal/config"
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUsulanNotFound     = errors.New("usulan penugasan not found")
+	ErrUsulanAccepted     = errors.New("usulan penugasan has already been accepted")
+	ErrAsesorRatioReached = errors.New("asesor would exceed the maximum number of asesi")
+	ErrAsesorNotAvailable = errors.New("asesor is not available for the schedule period")
+)
+
+// Pengecualian excludes an asesor from assessing a specific asesi.
+type Pengecualian struct {
+	AsesorID uint `json:"asesor_id"`
+	AsesiID  uint `json:"asesi_id"`
+}
+
+type PenugasanService interface {
+	ProposePenugasan(ctx context.Context, jadwalID, userID uint, apl01IDs []uint, maksAsesiPerAsesor int, pengecualian []Pengecualian) (*models.UsulanPenugasan, error)
+	GetUsulan(ctx context.Context, jadwalID, id uint) (*models.UsulanPenugasan, error)
+	GetUsulanList(ctx context.Context, jadwalID uint) ([]models.UsulanPenugasan, error)
+	UpdateUsulanItem(ctx context.Context, jadwalID, id, itemID uint, asesorID *uint) (*models.UsulanPenugasan, error)
+	AcceptUsulan(ctx context.Context, jadwalID, id, userID uint) (*models.UsulanPenugasan, error)
+	GetPeserta(ctx context.Context, jadwalID uint) ([]models.JadwalPeserta, error)
+}
+
+type penugasanService struct {
+	usulanRepo           repositories.UsulanPenugasanRepository
+	jadwalRepo           repositories.JadwalRepository
+	apl01Repo            repositories.APL01Repository
+	asesorRepo           repositories.AsesorRepository
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository
+	konflikRepo          repositories.KonflikKepentinganRepository
+	ketersediaanService  KetersediaanService
+	config               *config.Config
+}
+
+func NewPenugasanService(
+	usulanRepo repositories.UsulanPenugasanRepository,
+	jadwalRepo repositories.JadwalRepository,
+	apl01Repo repositories.APL01Repository,
+	asesorRepo repositories.AsesorRepository,
+	asesorKompetensiRepo repositories.AsesorKompetensiRepository,
+	konflikRepo repositories.KonflikKepentinganRepository,
+	ketersediaanService KetersediaanService,
+	config *config.Config,
+) PenugasanService {
+	return &penugasanService{
+		usulanRepo:           usulanRepo,
+		jadwalRepo:           jadwalRepo,
+		apl01Repo:            apl01Repo,
+		asesorRepo:           asesorRepo,
+		asesorKompetensiRepo: asesorKompetensiRepo,
+		konflikRepo:          konflikRepo,
+		ketersediaanService:  ketersediaanService,
+		config:               config,
+	}
+}
+
+// ProposePenugasan assigns every asesi to a qualified, licensed and available
+// asesor without a conflict of interest, balancing the load while keeping each
+// asesor under the maximum ratio. Asesors already on the jadwal are preferred.
+// Asesi that cannot be placed are returned without an asesor and a reason.
+func (s *penugasanService) ProposePenugasan(ctx context.Context, jadwalID, userID uint, apl01IDs []uint, maksAsesiPerAsesor int, pengecualian []Pengecualian) (*models.UsulanPenugasan, error) {
+	ctx, span := startSpan(ctx, "PenugasanService.ProposePenugasan")
+	defer span.End()
+
+	jadwal, err := s.jadwalRepo.FindByID(ctx, jadwalID)
+	if err != nil {
+		return nil, fmt.Errorf("jadwal not found: %w", err)
+	}
+
+	if maksAsesiPerAsesor <= 0 {
+		maksAsesiPerAsesor = s.config.MaxAsesiPerAsesor
+	}
+
+	apl01s, err := s.loadAPL01(ctx, jadwal, apl01IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.candidates(ctx, jadwal)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make(map[uint]bool, len(apl01s))
+	for _, apl01 := range apl01s {
+		batch[apl01.ID] = true
+	}
+
+	load, err := s.existingLoad(ctx, jadwal.ID, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[Pengecualian]bool, len(pengecualian))
+	for _, p := range pengecualian {
+		excluded[p] = true
+	}
+
+	onJadwal := make(map[uint]bool, len(jadwal.Asesor))
+	for _, asesor := range jadwal.Asesor {
+		onJadwal[asesor.ID] = true
+	}
+
+	usulan := &models.UsulanPenugasan{
+		JadwalID:           jadwal.ID,
+		Status:             models.StatusUsulanDraft,
+		MaksAsesiPerAsesor: maksAsesiPerAsesor,
+		DibuatOleh:         userID,
+	}
+
+	for _, apl01 := range apl01s {
+		item := models.UsulanPenugasanItem{
+			APL01ID:   apl01.ID,
+			AsesiID:   apl01.AsesiID,
+			NamaAsesi: apl01.NamaLengkap,
+		}
+
+		var options []models.Asesor
+		conflicted, full := 0, 0
+		for i := range candidates {
+			asesor := &candidates[i]
+			if excluded[Pengecualian{AsesorID: asesor.ID, AsesiID: apl01.AsesiID}] {
+				conflicted++
+				continue
+			}
+
+			err := ensureNoConflict(ctx, s.konflikRepo, s.apl01Repo, asesor, apl01.AsesiID)
+			if errors.Is(err, ErrConflictOfInterest) {
+				conflicted++
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			if load[asesor.ID] >= maksAsesiPerAsesor {
+				full++
+				continue
+			}
+			options = append(options, *asesor)
+		}
+
+		switch {
+		case len(candidates) == 0:
+			item.Catatan = "no qualified asesor is available for the schedule period"
+		case len(options) == 0 && full > 0:
+			item.Catatan = "every available asesor has reached the maximum number of asesi"
+		case len(options) == 0:
+			item.Catatan = "every available asesor has a conflict of interest with this asesi"
+		default:
+			sort.SliceStable(options, func(i, j int) bool {
+				a, b := options[i], options[j]
+				if onJadwal[a.ID] != onJadwal[b.ID] {
+					return onJadwal[a.ID]
+				}
+				if load[a.ID] != load[b.ID] {
+					return load[a.ID] < load[b.ID]
+				}
+				return a.ID < b.ID
+			})
+			chosen := options[0].ID
+			item.AsesorID = &chosen
+			load[chosen]++
+		}
+
+		usulan.Items = append(usulan.Items, item)
+	}
+
+	err = s.usulanRepo.Create(ctx, usulan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create usulan penugasan: %w", err)
+	}
+
+	return s.usulanRepo.FindByID(ctx, usulan.ID)
+}
+
+func (s *penugasanService) GetUsulan(ctx context.Context, jadwalID, id uint) (*models.UsulanPenugasan, error) {
+	ctx, span := startSpan(ctx, "PenugasanService.GetUsulan")
+	defer span.End()
+
+	usulan, err := s.usulanRepo.FindByID(ctx, id)
+	if err != nil || usulan.JadwalID != jadwalID {
+		return nil, ErrUsulanNotFound
+	}
+	return usulan, nil
+}
+
+func (s *penugasanService) GetUsulanList(ctx context.Context, jadwalID uint) ([]models.UsulanPenugasan, error) {
+	ctx, span := startSpan(ctx, "PenugasanService.GetUsulanList")
+	defer span.End()
+
+	return s.usulanRepo.FindByJadwalID(ctx, jadwalID)
+}
+
+// UpdateUsulanItem lets staff replace or clear the proposed asesor of one
+// asesi. A replacement must satisfy the same rules as the proposal.
+func (s *penugasanService) UpdateUsulanItem(ctx context.Context, jadwalID, id, itemID uint, asesorID *uint) (*models.UsulanPenugasan, error) {
+	ctx, span := startSpan(ctx, "PenugasanService.UpdateUsulanItem")
+	defer span.End()
+
+	usulan, err := s.GetUsulan(ctx, jadwalID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if usulan.Status != models.StatusUsulanDraft {
+		return nil, ErrUsulanAccepted
+	}
+
+	var item *models.UsulanPenugasanItem
+	for i := range usulan.Items {
+		if usulan.Items[i].ID == itemID {
+			item = &usulan.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, errors.New("usulan penugasan item not found")
+	}
+
+	if asesorID != nil {
+		jadwal, err := s.jadwalRepo.FindByID(ctx, jadwalID)
+		if err != nil {
+			return nil, fmt.Errorf("jadwal not found: %w", err)
+		}
+
+		asesor, err := s.asesorRepo.FindByID(ctx, *asesorID)
+		if err != nil {
+			return nil, fmt.Errorf("asesor not found: %w", err)
+		}
+
+		err = s.ensureAssignable(ctx, jadwal, asesor)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ensureNoConflict(ctx, s.konflikRepo, s.apl01Repo, asesor, item.AsesiID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.ensureRatio(ctx, usulan, item.ID, asesor.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item.AsesorID = asesorID
+	item.Catatan = ""
+
+	err = s.usulanRepo.UpdateItem(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update usulan penugasan: %w", err)
+	}
+
+	return s.usulanRepo.FindByID(ctx, id)
+}
+
+// AcceptUsulan re-validates every proposed asesor and records the jadwal
+// participants.
+func (s *penugasanService) AcceptUsulan(ctx context.Context, jadwalID, id, userID uint) (*models.UsulanPenugasan, error) {
+	ctx, span := startSpan(ctx, "PenugasanService.AcceptUsulan")
+	defer span.End()
+
+	usulan, err := s.GetUsulan(ctx, jadwalID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if usulan.Status != models.StatusUsulanDraft {
+		return nil, ErrUsulanAccepted
+	}
+
+	jadwal, err := s.jadwalRepo.FindByID(ctx, jadwalID)
+	if err != nil {
+		return nil, fmt.Errorf("jadwal not found: %w", err)
+	}
+
+	asesors := make(map[uint]*models.Asesor)
+	for _, item := range usulan.Items {
+		if item.AsesorID == nil {
+			return nil, fmt.Errorf("asesi %s has no asesor assigned", item.NamaAsesi)
+		}
+
+		asesor, checked := asesors[*item.AsesorID]
+		if !checked {
+			asesor, err = s.asesorRepo.FindByID(ctx, *item.AsesorID)
+			if err != nil {
+				return nil, fmt.Errorf("asesor not found: %w", err)
+			}
+
+			err = s.ensureAssignable(ctx, jadwal, asesor)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", asesor.NamaLengkap, err)
+			}
+
+			err = s.ensureRatio(ctx, usulan, 0, asesor.ID)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", asesor.NamaLengkap, err)
+			}
+
+			asesors[asesor.ID] = asesor
+		}
+
+		err = ensureNoConflict(ctx, s.konflikRepo, s.apl01Repo, asesor, item.AsesiID)
+		if err != nil {
+			return nil, fmt.Errorf("%s and %s: %w", asesor.NamaLengkap, item.NamaAsesi, err)
+		}
+	}
+
+	err = s.usulanRepo.Accept(ctx, usulan, userID, time.Now(), func(peserta int64) error {
+		return ensureTUKCapacity(jadwal.TUK, peserta)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUsulanAccepted
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to accept usulan penugasan: %w", err)
+	}
+
+	return s.usulanRepo.FindByID(ctx, id)
+}
+
+func (s *penugasanService) GetPeserta(ctx context.Context, jadwalID uint) ([]models.JadwalPeserta, error) {
+	ctx, span := startSpan(ctx, "PenugasanService.GetPeserta")
+	defer span.End()
+
+	return s.jadwalRepo.FindPeserta(ctx, jadwalID)
+}
+
+func (s *penugasanService) loadAPL01(ctx context.Context, jadwal *models.JadwalAsesmen, apl01IDs []uint) ([]*models.APL01, error) {
+	seen := make(map[uint]bool, len(apl01IDs))
+	var apl01s []*models.APL01
+	for _, apl01ID := range apl01IDs {
+		if seen[apl01ID] {
+			continue
+		}
+		seen[apl01ID] = true
+
+		apl01, err := s.apl01Repo.FindByID(ctx, apl01ID)
+		if err != nil {
+			return nil, fmt.Errorf("apl01 %d not found", apl01ID)
+		}
+		if apl01.Status != models.StatusAPL01Disetujui {
+			return nil, fmt.Errorf("apl01 %d has not been approved", apl01ID)
+		}
+		if apl01.KompetensiID != jadwal.KompetensiID {
+			return nil, fmt.Errorf("apl01 %d is for a different kompetensi than the jadwal", apl01ID)
+		}
+		apl01s = append(apl01s, apl01)
+	}
+
+	if len(apl01s) == 0 {
+		return nil, errors.New("at least one asesi is required")
+	}
+	return apl01s, nil
+}
+
+// candidates returns the asesors that may assess the jadwal: those already on
+// it plus those with a free slot covering it, all qualified and licensed for
+// the whole period.
+func (s *penugasanService) candidates(ctx context.Context, jadwal *models.JadwalAsesmen) ([]models.Asesor, error) {
+	free, err := s.ketersediaanService.FindFreeAsesors(ctx, jadwal.KompetensiID, jadwal.TanggalMulai, jadwal.TanggalSelesai, jadwal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool)
+	var candidates []models.Asesor
+	for _, asesor := range append(append([]models.Asesor{}, jadwal.Asesor...), free...) {
+		if seen[asesor.ID] {
+			continue
+		}
+		seen[asesor.ID] = true
+
+		err := ensureAsesorEligible(ctx, s.asesorKompetensiRepo, &asesor, jadwal.KompetensiID, jadwal.TanggalMulai, jadwal.TanggalSelesai)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, asesor)
+	}
+	return candidates, nil
+}
+
+// existingLoad counts the participants each asesor already assesses on the
+// jadwal, ignoring the APL-01s being re-planned.
+func (s *penugasanService) existingLoad(ctx context.Context, jadwalID uint, ignore map[uint]bool) (map[uint]int, error) {
+	peserta, err := s.jadwalRepo.FindPeserta(ctx, jadwalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load peserta: %w", err)
+	}
+
+	load := make(map[uint]int)
+	for _, p := range peserta {
+		if p.AsesorID != nil && !ignore[p.APL01ID] {
+			load[*p.AsesorID]++
+		}
+	}
+	return load, nil
+}
+
+func (s *penugasanService) ensureAssignable(ctx context.Context, jadwal *models.JadwalAsesmen, asesor *models.Asesor) error {
+	err := ensureAsesorEligible(ctx, s.asesorKompetensiRepo, asesor, jadwal.KompetensiID, jadwal.TanggalMulai, jadwal.TanggalSelesai)
+	if err != nil {
+		return err
+	}
+
+	for _, assigned := range jadwal.Asesor {
+		if assigned.ID == asesor.ID {
+			return nil
+		}
+	}
+
+	free, err := s.ketersediaanService.FindFreeAsesors(ctx, jadwal.KompetensiID, jadwal.TanggalMulai, jadwal.TanggalSelesai, jadwal.ID)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range free {
+		if candidate.ID == asesor.ID {
+			return nil
+		}
+	}
+	return ErrAsesorNotAvailable
+}
+
+// ensureRatio checks that the asesor stays within the proposal ratio when
+// given the item, counting participants outside the proposal too.
+func (s *penugasanService) ensureRatio(ctx context.Context, usulan *models.UsulanPenugasan, itemID, asesorID uint) error {
+	batch := make(map[uint]bool, len(usulan.Items))
+	for _, item := range usulan.Items {
+		batch[item.APL01ID] = true
+	}
+
+	load, err := s.existingLoad(ctx, usulan.JadwalID, batch)
+	if err != nil {
+		return err
+	}
+
+	count := load[asesorID]
+	for _, item := range usulan.Items {
+		if item.ID != itemID && item.AsesorID != nil && *item.AsesorID == asesorID {
+			count++
+		}
+	}
+
+	if itemID != 0 {
+		count++
+	}
+
+	if count > usulan.MaksAsesiPerAsesor {
+		return ErrAsesorRatioReached
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"lsp-api/internal/certificate"
+	"lsp-api/internal/config"
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSertifikatNotFound      = errors.New("sertifikat not found")
+	ErrSertifikatAlreadyIssued = errors.New("a sertifikat has already been issued for this hasil asesmen")
+	ErrSertifikatNotRenewable  = errors.New("only an active sertifikat that has not expired can be renewed")
+)
+
+// SertifikatVerification is the public view of a certificate returned to
+// anyone checking its authenticity.
+type SertifikatVerification struct {
+	NomorSertifikat   string    `json:"nomor_sertifikat"`
+	NamaPemegang      string    `json:"nama_pemegang"`
+	KodeKompetensi    string    `json:"kode_kompetensi"`
+	NamaKompetensi    string    `json:"nama_kompetensi"`
+	TanggalTerbit     time.Time `json:"tanggal_terbit"`
+	BerlakuSampai     time.Time `json:"berlaku_sampai"`
+	Status            string    `json:"status"`
+	Valid             bool      `json:"valid"`
+	DiperbaruiMenjadi string    `json:"diperbarui_menjadi,omitempty"`
+}
+
+type SertifikatService interface {
+	IssueSertifikat(ctx context.Context, hasilAsesmenID, adminID uint) (*models.Sertifikat, error)
+	GetSertifikatByID(ctx context.Context, id, userID uint, role string) (*models.Sertifikat, error)
+	GetSertifikatList(ctx context.Context, userID uint, role string) ([]models.Sertifikat, error)
+	ChangeStatus(ctx context.Context, id, actorID uint, status, alasan string) (*models.Sertifikat, error)
+	RenewSertifikat(ctx context.Context, id, actorID uint, hasilAsesmenID *uint, alasan string) (*models.Sertifikat, error)
+	RenderPDF(ctx context.Context, sertifikat *models.Sertifikat) ([]byte, error)
+	VerifySertifikat(ctx context.Context, nomor string) (*SertifikatVerification, error)
+}
+
+type sertifikatService struct {
+	sertifikatRepo repositories.SertifikatRepository
+	hasilRepo      repositories.HasilAsesmenRepository
+	apl01Repo      repositories.APL01Repository
+	config         *config.Config
+}
+
+func NewSertifikatService(
+	sertifikatRepo repositories.SertifikatRepository,
+	hasilRepo repositories.HasilAsesmenRepository,
+	apl01Repo repositories.APL01Repository,
+	config *config.Config,
+) SertifikatService {
+	return &sertifikatService{
+		sertifikatRepo: sertifikatRepo,
+		hasilRepo:      hasilRepo,
+		apl01Repo:      apl01Repo,
+		config:         config,
+	}
+}
+
+func (s *sertifikatService) IssueSertifikat(ctx context.Context, hasilAsesmenID, adminID uint) (*models.Sertifikat, error) {
+	ctx, span := startSpan(ctx, "SertifikatService.IssueSertifikat")
+	defer span.End()
+
+	hasil, err := s.findIssuableHasil(ctx, hasilAsesmenID)
+	if err != nil {
+		return nil, err
+	}
+
+	apl01, err := s.apl01Repo.FindByID(ctx, hasil.APL01ID)
+	if err != nil {
+		return nil, ErrAPL01NotFound
+	}
+
+	now := time.Now()
+	sertifikat := &models.Sertifikat{
+		HasilAsesmenID:  &hasil.ID,
+		AsesiID:         hasil.AsesiID,
+		KompetensiID:    hasil.KompetensiID,
+		NamaPemegang:    apl01.NamaLengkap,
+		TanggalTerbit:   now,
+		BerlakuSampai:   now.AddDate(s.config.CertValidityYears, 0, 0),
+		Status:          models.StatusSertifikatAktif,
+		DiterbitkanOleh: adminID,
+	}
+
+	err = s.issue(ctx, sertifikat, hasil.Kompetensi.Kode)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sertifikatRepo.FindByID(ctx, sertifikat.ID)
+}
+
+func (s *sertifikatService) GetSertifikatByID(ctx context.Context, id, userID uint, role string) (*models.Sertifikat, error) {
+	ctx, span := startSpan(ctx, "SertifikatService.GetSertifikatByID")
+	defer span.End()
+
+	sertifikat, err := s.sertifikatRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrSertifikatNotFound
+	}
+
+	if role != models.RoleAdmin && sertifikat.AsesiID != userID {
+		return nil, ErrSertifikatNotFound
+	}
+
+	return sertifikat, nil
+}
+
+func (s *sertifikatService) GetSertifikatList(ctx context.Context, userID uint, role string) ([]models.Sertifikat, error) {
+	ctx, span := startSpan(ctx, "SertifikatService.GetSertifikatList")
+	defer span.End()
+
+	switch role {
+	case models.RoleAdmin:
+		return s.sertifikatRepo.FindAll(ctx, 0)
+	case models.RoleAsesi:
+		return s.sertifikatRepo.FindAll(ctx, userID)
+	default:
+		return []models.Sertifikat{}, nil
+	}
+}
+
+func (s *sertifikatService) ChangeStatus(ctx context.Context, id, actorID uint, status, alasan string) (*models.Sertifikat, error) {
+	ctx, span := startSpan(ctx, "SertifikatService.ChangeStatus")
+	defer span.End()
+
+	sertifikat, err := s.sertifikatRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrSertifikatNotFound
+	}
+
+	if alasan == "" {
+		return nil, errors.New("a reason is required to change the sertifikat status")
+	}
+
+	if !sertifikat.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, sertifikat.Status, status)
+	}
+
+	riwayat := &models.SertifikatRiwayat{
+		SertifikatID: sertifikat.ID,
+		DariStatus:   sertifikat.Status,
+		KeStatus:     status,
+		Alasan:       alasan,
+		OlehUserID:   actorID,
+	}
+
+	sertifikat.Status = status
+	sertifikat.Alasan = alasan
+
+	err = s.sertifikatRepo.ChangeStatus(ctx, sertifikat, riwayat)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: sertifikat status changed concurrently", ErrInvalidStatusTransition)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to change sertifikat status: %w", err)
+	}
+
+	return s.sertifikatRepo.FindByID(ctx, id)
+}
+
+// RenewSertifikat replaces an active certificate before it expires. The new
+// certificate continues the validity of the prior one, keeps its kompetensi
+// and may reference the hasil asesmen of the resertifikasi.
+func (s *sertifikatService) RenewSertifikat(ctx context.Context, id, actorID uint, hasilAsesmenID *uint, alasan string) (*models.Sertifikat, error) {
+	ctx, span := startSpan(ctx, "SertifikatService.RenewSertifikat")
+	defer span.End()
+
+	prior, err := s.sertifikatRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrSertifikatNotFound
+	}
+
+	now := time.Now()
+	if prior.Status != models.StatusSertifikatAktif || !prior.Berlaku(now) {
+		return nil, ErrSertifikatNotRenewable
+	}
+
+	if hasilAsesmenID != nil {
+		hasil, err := s.findIssuableHasil(ctx, *hasilAsesmenID)
+		if err != nil {
+			return nil, err
+		}
+		if hasil.AsesiID != prior.AsesiID || hasil.KompetensiID != prior.KompetensiID {
+			return nil, errors.New("hasil asesmen must belong to the same asesi and kompetensi")
+		}
+	}
+
+	if alasan == "" {
+		alasan = "resertifikasi"
+	}
+
+	renewal := &models.Sertifikat{
+		HasilAsesmenID:  hasilAsesmenID,
+		SebelumnyaID:    &prior.ID,
+		AsesiID:         prior.AsesiID,
+		KompetensiID:    prior.KompetensiID,
+		NamaPemegang:    prior.NamaPemegang,
+		TanggalTerbit:   now,
+		BerlakuSampai:   prior.BerlakuSampai.AddDate(s.config.CertValidityYears, 0, 0),
+		Status:          models.StatusSertifikatAktif,
+		DiterbitkanOleh: actorID,
+	}
+
+	riwayat := &models.SertifikatRiwayat{
+		SertifikatID: prior.ID,
+		DariStatus:   prior.Status,
+		KeStatus:     models.StatusSertifikatDiperbarui,
+		Alasan:       alasan,
+		OlehUserID:   actorID,
+	}
+
+	prior.Status = models.StatusSertifikatDiperbarui
+	prior.Alasan = alasan
+
+	kode := prior.Kompetensi.Kode
+	format := s.config.CertNumberFormat
+	scope := certificate.Scope(format, kode, renewal.TanggalTerbit)
+
+	err = s.sertifikatRepo.Renew(ctx, prior, riwayat, renewal, scope, func(seq uint64) string {
+		return certificate.FormatNumber(format, kode, renewal.TanggalTerbit, seq)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSertifikatNotRenewable
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to renew sertifikat: %w", err)
+	}
+
+	return s.sertifikatRepo.FindByID(ctx, renewal.ID)
+}
+
+func (s *sertifikatService) RenderPDF(ctx context.Context, sertifikat *models.Sertifikat) ([]byte, error) {
+	ctx, span := startSpan(ctx, "SertifikatService.RenderPDF")
+	defer span.End()
+
+	return certificate.RenderPDF(sertifikat, s.verifyURL(sertifikat.NomorSertifikat))
+}
+
+func (s *sertifikatService) VerifySertifikat(ctx context.Context, nomor string) (*SertifikatVerification, error) {
+	ctx, span := startSpan(ctx, "SertifikatService.VerifySertifikat")
+	defer span.End()
+
+	sertifikat, err := s.sertifikatRepo.FindByNomor(ctx, nomor)
+	if err != nil {
+		return nil, ErrSertifikatNotFound
+	}
+
+	now := time.Now()
+	verification := &SertifikatVerification{
+		NomorSertifikat: sertifikat.NomorSertifikat,
+		NamaPemegang:    sertifikat.NamaPemegang,
+		KodeKompetensi:  sertifikat.Kompetensi.Kode,
+		NamaKompetensi:  sertifikat.Kompetensi.Nama,
+		TanggalTerbit:   sertifikat.TanggalTerbit,
+		BerlakuSampai:   sertifikat.BerlakuSampai,
+		Status:          sertifikat.StatusAt(now),
+		Valid:           sertifikat.Berlaku(now),
+	}
+
+	if sertifikat.Status == models.StatusSertifikatDiperbarui {
+		renewal, err := s.sertifikatRepo.FindBySebelumnyaID(ctx, sertifikat.ID)
+		if err == nil {
+			verification.DiperbaruiMenjadi = renewal.NomorSertifikat
+		}
+	}
+
+	return verification, nil
+}
+
+// findIssuableHasil loads a signed-off kompeten result that has no
+// certificate yet.
+func (s *sertifikatService) findIssuableHasil(ctx context.Context, hasilAsesmenID uint) (*models.HasilAsesmen, error) {
+	hasil, err := s.hasilRepo.FindByID(ctx, hasilAsesmenID)
+	if err != nil {
+		return nil, ErrHasilAsesmenNotFound
+	}
+
+	if hasil.Status != models.StatusHasilDitandatangani {
+		return nil, errors.New("hasil asesmen must be signed off before a sertifikat is issued")
+	}
+
+	if hasil.Rekomendasi != models.KeputusanKompeten {
+		return nil, errors.New("sertifikat can only be issued to an asesi declared kompeten")
+	}
+
+	_, err = s.sertifikatRepo.FindByHasilAsesmenID(ctx, hasil.ID)
+	if err == nil {
+		return nil, ErrSertifikatAlreadyIssued
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return hasil, nil
+}
+
+// issue stores the certificate under the next number of its sequence scope.
+func (s *sertifikatService) issue(ctx context.Context, sertifikat *models.Sertifikat, kode string) error {
+	format := s.config.CertNumberFormat
+	scope := certificate.Scope(format, kode, sertifikat.TanggalTerbit)
+
+	err := s.sertifikatRepo.Issue(ctx, sertifikat, scope, func(seq uint64) string {
+		return certificate.FormatNumber(format, kode, sertifikat.TanggalTerbit, seq)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to issue sertifikat: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sertifikatService) verifyURL(nomor string) string {
+	return fmt.Sprintf("%s/verify/%s", s.config.AppBaseURL, url.PathEscape(nomor))
+}
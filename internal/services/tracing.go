@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan opens the span of a service method. The tracer is resolved on
+// every call so the provider installed at startup is always the one used.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer("lsp-api/internal/services").Start(ctx, name)
+}
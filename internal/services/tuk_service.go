@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lsp-api/internal/models"
+	"lsp-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTUKNotVerified          = errors.New("tuk verification is not valid for the schedule period")
+	ErrTUKKompetensiNotAllowed = errors.New("kompetensi cannot be assessed at this tuk")
+	ErrTUKCapacityExceeded     = errors.New("tuk capacity would be exceeded")
+)
+
+type TUKService interface {
+	CreateTUK(ctx context.Context, data *models.TUK, kompetensiIDs []uint) (*models.TUK, error)
+	UpdateTUK(ctx context.Context, id uint, data *models.TUK, kompetensiIDs []uint) (*models.TUK, error)
+	DeleteTUK(ctx context.Context, id uint) error
+	GetTUKByID(ctx context.Context, id uint) (*models.TUK, error)
+	GetAllTUK(ctx context.Context) ([]models.TUK, error)
+}
+
+type tukService struct {
+	tukRepo        repositories.TUKRepository
+	kompetensiRepo repositories.KompetensiRepository
+}
+
+func NewTUKService(tukRepo repositories.TUKRepository, kompetensiRepo repositories.KompetensiRepository) TUKService {
+	return &tukService{
+		tukRepo:        tukRepo,
+		kompetensiRepo: kompetensiRepo,
+	}
+}
+
+func (s *tukService) CreateTUK(ctx context.Context, data *models.TUK, kompetensiIDs []uint) (*models.TUK, error) {
+	ctx, span := startSpan(ctx, "TUKService.CreateTUK")
+	defer span.End()
+
+	_, err := s.tukRepo.FindByKode(ctx, data.Kode)
+	if err == nil {
+		return nil, errors.New("tuk with this kode already exists")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	err = s.prepare(ctx, data, kompetensiIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tukRepo.Create(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tuk: %w", err)
+	}
+
+	return s.tukRepo.FindByID(ctx, data.ID)
+}
+
+func (s *tukService) UpdateTUK(ctx context.Context, id uint, data *models.TUK, kompetensiIDs []uint) (*models.TUK, error) {
+	ctx, span := startSpan(ctx, "TUKService.UpdateTUK")
+	defer span.End()
+
+	tuk, err := s.tukRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("tuk not found: %w", err)
+	}
+
+	if tuk.Kode != data.Kode {
+		existing, err := s.tukRepo.FindByKode(ctx, data.Kode)
+		if err == nil && existing.ID != id {
+			return nil, errors.New("kode already used by another tuk")
+		} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	err = s.prepare(ctx, data, kompetensiIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tuk.Kode = data.Kode
+	tuk.Nama = data.Nama
+	tuk.Jenis = data.Jenis
+	tuk.Alamat = data.Alamat
+	tuk.Kapasitas = data.Kapasitas
+	tuk.Fasilitas = data.Fasilitas
+	tuk.StatusVerifikasi = data.StatusVerifikasi
+	tuk.TanggalVerifikasi = data.TanggalVerifikasi
+	tuk.BerlakuSampai = data.BerlakuSampai
+	tuk.Kompetensi = data.Kompetensi
+
+	err = s.tukRepo.Update(ctx, tuk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tuk: %w", err)
+	}
+
+	return s.tukRepo.FindByID(ctx, id)
+}
+
+func (s *tukService) DeleteTUK(ctx context.Context, id uint) error {
+	ctx, span := startSpan(ctx, "TUKService.DeleteTUK")
+	defer span.End()
+
+	_, err := s.tukRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("tuk not found: %w", err)
+	}
+
+	return s.tukRepo.Delete(ctx, id)
+}
+
+func (s *tukService) GetTUKByID(ctx context.Context, id uint) (*models.TUK, error) {
+	ctx, span := startSpan(ctx, "TUKService.GetTUKByID")
+	defer span.End()
+
+	return s.tukRepo.FindByID(ctx, id)
+}
+
+func (s *tukService) GetAllTUK(ctx context.Context) ([]models.TUK, error) {
+	ctx, span := startSpan(ctx, "TUKService.GetAllTUK")
+	defer span.End()
+
+	return s.tukRepo.FindAll(ctx)
+}
+
+// prepare validates the verification data and resolves the allowed kompetensi.
+func (s *tukService) prepare(ctx context.Context, data *models.TUK, kompetensiIDs []uint) error {
+	if data.StatusVerifikasi == models.StatusVerifikasiTUKTerverifikasi && (data.TanggalVerifikasi == nil || data.BerlakuSampai == nil) {
+		return errors.New("verified tuk requires verification and expiry dates")
+	}
+
+	if data.TanggalVerifikasi != nil && data.BerlakuSampai != nil && data.BerlakuSampai.Before(*data.TanggalVerifikasi) {
+		return errors.New("verification expiry must not be before the verification date")
+	}
+
+	kompetensi, err := s.kompetensiRepo.FindByIDs(ctx, kompetensiIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find kompetensi: %w", err)
+	}
+
+	if len(kompetensi) != len(kompetensiIDs) {
+		return errors.New("one or more kompetensi not found")
+	}
+
+	data.Kompetensi = kompetensi
+	return nil
+}
+
+// ensureTUKSuitable checks that an assessment of the kompetensi can be held
+// at the TUK for the whole schedule period.
+func ensureTUKSuitable(tuk *models.TUK, kompetensiID uint, start, end time.Time, peserta int64) error {
+	if !tuk.Terverifikasi(start) || !tuk.Terverifikasi(end) {
+		return ErrTUKNotVerified
+	}
+	if !tuk.MenerimaKompetensi(kompetensiID) {
+		return ErrTUKKompetensiNotAllowed
+	}
+	return ensureTUKCapacity(tuk, peserta)
+}
+
+// ensureTUKCapacity checks that the participants of a jadwal fit the TUK.
+// Jadwal without a TUK, and TUK registered before capacities were recorded,
+// are not limited.
+func ensureTUKCapacity(tuk *models.TUK, peserta int64) error {
+	if tuk == nil || tuk.Kapasitas <= 0 || peserta <= int64(tuk.Kapasitas) {
+		return nil
+	}
+	return fmt.Errorf("%w: %d peserta for %d seats", ErrTUKCapacityExceeded, peserta, tuk.Kapasitas)
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorageRejectsTraversal(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	keys := []string{
+		"",
+		"/",
+		"..",
+		"../outside.pdf",
+		"dokumen/../../outside.pdf",
+		"dokumen/2026/../../../etc/passwd",
+		"dokumen/..",
+	}
+
+	for _, key := range keys {
+		if err := s.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+			t.Errorf("Put(%q) succeeded", key)
+		}
+		if _, err := s.Get(ctx, key); err == nil {
+			t.Errorf("Get(%q) succeeded", key)
+		}
+		if err := s.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) succeeded", key)
+		}
+	}
+}
+
+func TestLocalStorageKeepsKeysInBaseDir(t *testing.T) {
+	baseDir := t.TempDir()
+	s, err := NewLocalStorage(baseDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// A leading slash is relative to the base directory, not the filesystem
+	err = s.Put(ctx, "/dokumen/2026/10/a.pdf", strings.NewReader("isi"), 3, "application/pdf")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "dokumen", "2026", "10", "a.pdf")); err != nil {
+		t.Fatalf("file not stored under the base directory: %v", err)
+	}
+
+	content, err := s.Get(ctx, "dokumen/2026/10/a.pdf")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	b, _ := io.ReadAll(content)
+	content.Close()
+	if string(b) != "isi" {
+		t.Errorf("Get returned %q", b)
+	}
+
+	err = s.Delete(ctx, "dokumen/2026/10/a.pdf")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, "dokumen/2026/10/a.pdf"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrObjectNotFound", err)
+	}
+	if err := s.Delete(ctx, "dokumen/2026/10/a.pdf"); err != nil {
+		t.Errorf("deleting a missing object: %v", err)
+	}
+}
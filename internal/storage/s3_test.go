@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal in-process stand-in for an S3-compatible server. It
+// serves path-style bucket and object requests and ignores signatures.
+type fakeS3 struct {
+	mu      sync.Mutex
+	buckets map[string]bool
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	content     []byte
+	contentType string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+
+	fake := &fakeS3{buckets: map[string]bool{}, objects: map[string]fakeObject{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeS3) object(name string) (fakeObject, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	object, ok := f.objects[name]
+	return object, ok
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead:
+			if !f.buckets[bucket] {
+				writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+			}
+		case http.MethodPut:
+			f.buckets[bucket] = true
+		default:
+			writeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+		}
+		return
+	}
+
+	if !f.buckets[bucket] {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	name := bucket + "/" + key
+
+	switch r.Method {
+	case http.MethodPut:
+		content, err := readS3Body(r)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[name] = fakeObject{content: content, contentType: r.Header.Get("Content-Type")}
+		w.Header().Set("ETag", `"fake-etag"`)
+	case http.MethodHead, http.MethodGet:
+		object, ok := f.objects[name]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", `"fake-etag"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", object.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.content)))
+		if disposition := r.URL.Query().Get("response-content-disposition"); disposition != "" {
+			w.Header().Set("Content-Disposition", disposition)
+		}
+		if r.Method == http.MethodGet {
+			w.Write(object.content)
+		}
+	case http.MethodDelete:
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+// readS3Body decodes the aws-chunked encoding clients use for streaming
+// uploads over plain HTTP, or returns the body as is.
+func readS3Body(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var content bytes.Buffer
+	reader := bufio.NewReader(r.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return content.Bytes(), nil
+		}
+		_, err = io.CopyN(&content, reader, size)
+		if err != nil {
+			return nil, err
+		}
+		_, err = reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func newTestS3Storage(t *testing.T, server *httptest.Server) *S3Storage {
+	t.Helper()
+
+	s, err := NewS3Storage(S3Options{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+		Bucket:    "lsp-documents",
+		Region:    "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage: %v", err)
+	}
+	return s
+}
+
+func TestS3StorageCreatesBucket(t *testing.T) {
+	fake, server := newFakeS3(t)
+	newTestS3Storage(t, server)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if !fake.buckets["lsp-documents"] {
+		t.Errorf("missing bucket was not created")
+	}
+}
+
+func TestS3StoragePutGetDelete(t *testing.T) {
+	fake, server := newFakeS3(t)
+	s := newTestS3Storage(t, server)
+	ctx := context.Background()
+
+	content := "%PDF-1.4 isi dokumen"
+	err := s.Put(ctx, "apl01/2026/10/a.pdf", strings.NewReader(content), int64(len(content)), "application/pdf")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if object, _ := fake.object("lsp-documents/apl01/2026/10/a.pdf"); string(object.content) != content || object.contentType != "application/pdf" {
+		t.Fatalf("stored object %q of type %q", object.content, object.contentType)
+	}
+
+	reader, err := s.Get(ctx, "apl01/2026/10/a.pdf")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || string(got) != content {
+		t.Errorf("Get returned %q, %v", got, err)
+	}
+
+	err = s.Delete(ctx, "apl01/2026/10/a.pdf")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.object("lsp-documents/apl01/2026/10/a.pdf"); ok {
+		t.Errorf("object still stored after Delete")
+	}
+
+	_, err = s.Get(ctx, "apl01/2026/10/a.pdf")
+	if !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestS3StoragePresignedURL(t *testing.T) {
+	_, server := newFakeS3(t)
+	s := newTestS3Storage(t, server)
+	ctx := context.Background()
+
+	content := "isi sertifikat"
+	err := s.Put(ctx, "sertifikat/b.pdf", strings.NewReader(content), int64(len(content)), "application/pdf")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	link, err := s.PresignedURL(ctx, "sertifikat/b.pdf", 15*time.Minute, "Sertifikat Budi.pdf")
+	if err != nil {
+		t.Fatalf("PresignedURL: %v", err)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", link, err)
+	}
+	query := parsed.Query()
+	if parsed.Path != "/lsp-documents/sertifikat/b.pdf" {
+		t.Errorf("presigned path %s", parsed.Path)
+	}
+	if query.Get("X-Amz-Expires") != "900" || query.Get("X-Amz-Signature") == "" {
+		t.Errorf("presigned URL is not a 15 minute signed URL: %s", link)
+	}
+	if want := `attachment; filename="Sertifikat Budi.pdf"`; query.Get("response-content-disposition") != want {
+		t.Errorf("content disposition %q, want %q", query.Get("response-content-disposition"), want)
+	}
+
+	resp, err := http.Get(link)
+	if err != nil {
+		t.Fatalf("downloading presigned URL: %v", err)
+	}
+	defer resp.Body.Close()
+	got, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(got) != content {
+		t.Errorf("presigned download returned %d %q", resp.StatusCode, got)
+	}
+}
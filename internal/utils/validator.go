@@ -44,7 +44,17 @@ func getErrorMessage(e validator.FieldError) string {
 		return "Nilai terlalu pendek"
 	case "max":
 		return "Nilai terlalu panjang"
+	case "datetime":
+		return "Format tanggal tidak valid"
+	case "oneof":
+		return "Nilai tidak diizinkan"
+	case "len":
+		return "Panjang nilai tidak sesuai"
+	case "numeric":
+		return "Nilai harus berupa angka"
 	default:
 		return "Validasi gagal pada field ini"
 	}
-}
\ No newline at end of file
+}
+
+const DateLayout = "2006-01-02"
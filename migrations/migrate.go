@@ -16,6 +16,30 @@ func RunMigrations(db *gorm.DB) error {
 		&models.User{},
 		&models.Asesor{},
 		&models.Kompetensi{},
+		&models.Dokumen{},
+		&models.AsesorKompetensi{},
+		&models.AsesorMedia{},
+		&models.UnitKompetensi{},
+		&models.Elemen{},
+		&models.KriteriaUnjukKerja{},
+		&models.AsesorInvitation{},
+		&models.AsesorKetersediaan{},
+		&models.KonflikKepentingan{},
+		&models.TUK{},
+		&models.JadwalAsesmen{},
+		&models.JadwalPeserta{},
+		&models.UsulanPenugasan{},
+		&models.UsulanPenugasanItem{},
+		&models.APL01{},
+		&models.APL01Dokumen{},
+		&models.APL01Riwayat{},
+		&models.APL02{},
+		&models.APL02Item{},
+		&models.HasilAsesmen{},
+		&models.HasilAsesmenUnit{},
+		&models.Sertifikat{},
+		&models.SertifikatRiwayat{},
+		&models.SertifikatCounter{},
 	)
 
 	if err != nil {
@@ -23,6 +47,14 @@ func RunMigrations(db *gorm.DB) error {
 		return err
 	}
 
+	// Accounts created before roles existed were all admins; without a role
+	// they would be locked out of every protected route
+	err = db.Model(&models.User{}).Where("role = '' OR role IS NULL").Update("role", models.RoleAdmin).Error
+	if err != nil {
+		log.Printf("Error backfilling user roles: %v\n", err)
+		return err
+	}
+
 	log.Println("Migrations completed successfully")
 	return nil
-}
\ No newline at end of file
+}